@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+)
+
+// maxARIMADifferencing caps d and D, beyond which forecasts explode
+const maxARIMADifferencing = 2
+
+// newAdvancedPanel returns a collapsible form for the model settings power
+// users may want to tune, and a function that fills its fields from settings
+func newAdvancedPanel(status *statusBar) (fyne.CanvasObject, func(Settings)) {
+	newField := func(hint string) *widget.Entry {
+		e := widget.NewEntry()
+		e.SetPlaceHolder(hint)
+		return e
+	}
+	p, d, q := newField("p"), newField("d"), newField("q")
+	sp, sd, sq, period := newField("P"), newField("D"), newField("Q"), newField("s")
+	remoteURL := newField("https://models.example.com/predict")
+	bandPeriod, bandWidth := newField(lang.L("period")), newField(lang.L("deviations"))
+	benchmark := newField(benchmarkSymbol)
+	priceColor, predictionColor := newField("#ff0000"), newField("#00ff00")
+	lineWidth := newField(lang.L("points"))
+	dashedCheck := widget.NewCheck(lang.L("Dashed prediction"), nil)
+	legendSelect := newLocalSelect(legendPositions, nil)
+	logCheck := widget.NewCheck(lang.L("Log prices"), nil)
+	diffCheck := widget.NewCheck(lang.L("First differences"), nil)
+	winsorCheck := widget.NewCheck(fmt.Sprintf(lang.L("Winsorize %g%% tails"), winsorizeTail*100), nil)
+
+	setFields := func(s Settings) {
+		o := s.ARIMA
+		for _, f := range []struct {
+			entry *widget.Entry
+			value int
+		}{{p, o.P}, {d, o.D}, {q, o.Q}, {sp, o.SeasonalP}, {sd, o.SeasonalD}, {sq, o.SeasonalQ}, {period, o.Period}} {
+			f.entry.SetText(strconv.Itoa(f.value))
+		}
+		remoteURL.SetText(s.RemoteModelURL)
+		bandPeriod.SetText(strconv.Itoa(s.Bollinger.Period))
+		bandWidth.SetText(strconv.FormatFloat(s.Bollinger.Deviations, 'g', -1, 64))
+		benchmark.SetText(s.Benchmark)
+		priceColor.SetText(s.SeriesStyle.PriceColor)
+		predictionColor.SetText(s.SeriesStyle.PredictionColor)
+		lineWidth.SetText(strconv.FormatFloat(s.SeriesStyle.LineWidth, 'g', -1, 64))
+		dashedCheck.SetChecked(s.SeriesStyle.DashedPrediction)
+		legendSelect.SetValue(s.SeriesStyle.Legend)
+		logCheck.SetChecked(s.Preprocessing.Log)
+		diffCheck.SetChecked(s.Preprocessing.Difference)
+		winsorCheck.SetChecked(s.Preprocessing.Winsorize)
+	}
+	setFields(settings.Get())
+
+	form := widget.NewForm(
+		widget.NewFormItem(lang.L("Order (p, d, q)"), container.NewGridWithColumns(3, p, d, q)),
+		widget.NewFormItem(lang.L("Seasonal (P, D, Q, s)"), container.NewGridWithColumns(4, sp, sd, sq, period)),
+		widget.NewFormItem(lang.L("Preprocessing"), container.NewHBox(logCheck, diffCheck, winsorCheck)),
+		widget.NewFormItem(lang.L("Model server URL"), remoteURL),
+		widget.NewFormItem(lang.L("Bollinger (period, σ)"), container.NewGridWithColumns(2, bandPeriod, bandWidth)),
+		widget.NewFormItem(lang.L("Benchmark symbol"), benchmark),
+		widget.NewFormItem(lang.L("Price, prediction colors"), container.NewGridWithColumns(2, priceColor, predictionColor)),
+		widget.NewFormItem(lang.L("Line width"), container.NewGridWithColumns(2, lineWidth, dashedCheck)),
+		widget.NewFormItem(lang.L("Legend"), legendSelect),
+	)
+	form.SubmitText = lang.L("Apply")
+	form.OnSubmit = func() {
+		var values [7]int
+		for i, e := range []*widget.Entry{p, d, q, sp, sd, sq, period} {
+			v, err := strconv.Atoi(e.Text)
+			if err != nil || v < 0 {
+				status.Set("ARIMA orders must be whole numbers of at least 0")
+				return
+			}
+			values[i] = v
+		}
+		o := ARIMAOrder{P: values[0], D: values[1], Q: values[2], SeasonalP: values[3], SeasonalD: values[4], SeasonalQ: values[5], Period: values[6]}
+		if err := validateARIMAOrder(o, settings.Get().LookbackMonths); err != nil {
+			status.Set("Invalid ARIMA order: %v", err)
+			return
+		}
+		n, err := strconv.Atoi(bandPeriod.Text)
+		if err != nil || n < 2 {
+			status.Set("The Bollinger period must be a whole number of at least 2")
+			return
+		}
+		k, err := strconv.ParseFloat(bandWidth.Text, 64)
+		if err != nil || k <= 0 {
+			status.Set("The Bollinger width must be a positive number of standard deviations")
+			return
+		}
+		for _, e := range []*widget.Entry{priceColor, predictionColor} {
+			if _, err := parseHexColor(e.Text); e.Text != "" && err != nil {
+				status.Set("Line colors must be written as #rrggbb or #rrggbbaa")
+				return
+			}
+		}
+		width, err := strconv.ParseFloat(lineWidth.Text, 64)
+		if err != nil || width <= 0 {
+			status.Set("The line width must be a positive number of points")
+			return
+		}
+		if u, err := url.Parse(remoteURL.Text); remoteURL.Text != "" && (err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https")) {
+			status.Set("The model server URL must be an http or https address")
+			return
+		}
+
+		s := settings.Get()
+		s.ARIMA = o
+		s.RemoteModelURL = remoteURL.Text
+		s.Preprocessing = Preprocessing{Log: logCheck.Checked, Difference: diffCheck.Checked, Winsorize: winsorCheck.Checked}
+		s.Bollinger.Period, s.Bollinger.Deviations = n, k
+		s.Benchmark = strings.ToUpper(strings.TrimSpace(benchmark.Text))
+		if s.Benchmark == "" {
+			s.Benchmark = benchmarkSymbol
+		}
+		s.SeriesStyle = SeriesStyle{PriceColor: priceColor.Text, PredictionColor: predictionColor.Text, LineWidth: width, DashedPrediction: dashedCheck.Checked, Legend: legendSelect.Value()}
+		if err := settings.Set(s); err != nil {
+			log.Println("Error saving settings:", err)
+		}
+		status.Set("Model settings applied, ARIMA order %s", newARIMA(o))
+	}
+
+	return widget.NewAccordion(widget.NewAccordionItem(lang.L("Advanced"), form)), setFields
+}
+
+// validateARIMAOrder rejects orders the ARIMA model cannot fit sensibly,
+// including seasonal ones needing more history than months of it, where 0
+// stands for all of it
+func validateARIMAOrder(o ARIMAOrder, months int) error {
+	if o.D > maxARIMADifferencing || o.SeasonalD > maxARIMADifferencing {
+		return fmt.Errorf("differencing orders above %d are not supported", maxARIMADifferencing)
+	}
+	if o.SeasonalP+o.SeasonalD+o.SeasonalQ > 0 && o.Period < 2 {
+		return fmt.Errorf("a seasonal order needs a period s of at least 2")
+	}
+	need := o.Period*(o.SeasonalD+max(o.SeasonalP, o.SeasonalQ)) + o.D + max(o.P, o.Q)
+	if days := months * tradingDaysPerYear / 12; months > 0 && o.SeasonalP+o.SeasonalD+o.SeasonalQ > 0 && need >= days {
+		return fmt.Errorf("a period of %d needs more than the %d trading days in %d months of history", o.Period, days, months)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/text"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Annotation kinds
+const (
+	annotationNote  = "note"
+	annotationLevel = "level"
+)
+
+// annotationColor draws the user's notes and levels
+var annotationColor = color.RGBA{R: 230, G: 170, A: 255}
+
+// symbolAnnotations returns the annotations saved for symbol
+func symbolAnnotations(symbol string) []Annotation {
+	return settings.Get().Annotations[symbol]
+}
+
+// saveAnnotations replaces the annotations saved for symbol
+func saveAnnotations(symbol string, list []Annotation) {
+	s := settings.Get()
+	annotations := make(map[string][]Annotation, len(s.Annotations)+1)
+	for k, v := range s.Annotations {
+		annotations[k] = v
+	}
+	if len(list) == 0 {
+		delete(annotations, symbol)
+	} else {
+		annotations[symbol] = list
+	}
+	s.Annotations = annotations
+	if err := settings.Set(s); err != nil {
+		log.Println("Error saving settings:", err)
+	}
+}
+
+// annotationBar returns the first bar on or after date, so notes stay on the
+// right bar whatever the bar size
+func annotationBar(dates []string, date string) (int, bool) {
+	i := sort.SearchStrings(dates, date)
+	return i, i < len(dates)
+}
+
+// addAnnotations draws the notes and levels of c onto p. Levels run from the
+// bar they were pinned to across the rest of the chart
+func addAnnotations(p *plot.Plot, c chartData) error {
+	if len(c.Dates) != len(c.Prices) {
+		return nil
+	}
+	_, fg := chartPalette()
+	end := float64(len(c.Prices)+len(c.Predictions)) - 0.5
+	style := draw.LineStyle{Color: annotationColor, Width: vg.Points(1), Dashes: []vg.Length{vg.Points(6), vg.Points(3)}}
+
+	var notes plotter.XYLabels
+	for _, a := range c.Annotations {
+		i, ok := annotationBar(c.Dates, a.Date)
+		if !ok {
+			continue
+		}
+		switch a.Kind {
+		case annotationLevel:
+			level, err := plotter.NewLine(plotter.XYs{{X: float64(i), Y: a.Price}, {X: end, Y: a.Price}})
+			if err != nil {
+				return err
+			}
+			level.LineStyle = style
+			p.Add(level)
+			notes.XYs = append(notes.XYs, plotter.XY{X: end, Y: a.Price})
+			notes.Labels = append(notes.Labels, formatNumber(a.Price, 2))
+		case annotationNote:
+			notes.XYs = append(notes.XYs, plotter.XY{X: float64(i), Y: a.Price})
+			notes.Labels = append(notes.Labels, "▼ "+a.Text)
+		}
+	}
+	if len(notes.XYs) == 0 {
+		return nil
+	}
+
+	labels, err := plotter.NewLabels(notes)
+	if err != nil {
+		return err
+	}
+	for i := range labels.TextStyle {
+		labels.TextStyle[i].Color = fg
+		labels.TextStyle[i].YAlign = text.YBottom
+		labels.TextStyle[i].XAlign = text.XCenter
+		if labels.XYs[i].X == end {
+			labels.TextStyle[i].XAlign = text.XRight
+		}
+	}
+	p.Add(labels)
+	return nil
+}
+
+// askNote asks for the text of a note at a and passes it to add
+func askNote(c fyne.Canvas, a Annotation, add func(Annotation)) {
+	entry := widget.NewEntry()
+	entry.SetPlaceHolder(lang.L("Note"))
+	var pop *widget.PopUp
+	submit := func() {
+		pop.Hide()
+		if entry.Text != "" {
+			a.Text = entry.Text
+			add(a)
+		}
+	}
+	entry.OnSubmitted = func(string) { submit() }
+	buttons := container.NewHBox(layout.NewSpacer(),
+		widget.NewButton(lang.L("Cancel"), func() { pop.Hide() }),
+		widget.NewButtonWithIcon(lang.L("Add"), theme.ConfirmIcon(), submit))
+	pop = widget.NewModalPopUp(container.NewVBox(widget.NewLabel(fmt.Sprintf(lang.L("Note on %s"), formatDay(a.Date))), entry, buttons), c)
+	pop.Resize(fyne.NewSize(320, pop.MinSize().Height))
+	pop.Show()
+	c.Focus(entry)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+)
+
+// A daily return is unusual when it is more than anomalyThreshold standard
+// deviations away from the mean of the anomalyWindow returns before it
+const (
+	anomalyWindow    = 20
+	anomalyThreshold = 3.0
+)
+
+// anomaly is a day whose move stands out from the recent ones
+type anomaly struct {
+	Index  int // bar in the price series
+	Date   string
+	Return float64 // percent change from the previous close
+	Z      float64 // rolling z-score of the return
+}
+
+// detectAnomalies flags the days whose return has a rolling z-score beyond anomalyThreshold
+func detectAnomalies(data []StockData) []anomaly {
+	returns := make([]float64, 0, len(data))
+	for i := 1; i < len(data); i++ {
+		if data[i-1].Close == 0 {
+			returns = append(returns, 0)
+			continue
+		}
+		returns = append(returns, data[i].Close/data[i-1].Close-1)
+	}
+
+	var out []anomaly
+	for i := anomalyWindow; i < len(returns); i++ {
+		window := returns[i-anomalyWindow : i]
+		mean := 0.0
+		for _, r := range window {
+			mean += r
+		}
+		mean /= float64(len(window))
+		variance := 0.0
+		for _, r := range window {
+			variance += (r - mean) * (r - mean)
+		}
+		sd := math.Sqrt(variance / float64(len(window)-1))
+		if sd == 0 {
+			continue
+		}
+
+		if z := (returns[i] - mean) / sd; math.Abs(z) >= anomalyThreshold {
+			out = append(out, anomaly{Index: i + 1, Date: data[i+1].Date, Return: returns[i] * 100, Z: z})
+		}
+	}
+	return out
+}
+
+// anomalyIndexes returns the bars of the anomalies for marking them on the chart
+func anomalyIndexes(anomalies []anomaly) []int {
+	out := make([]int, len(anomalies))
+	for i, a := range anomalies {
+		out[i] = a.Index
+	}
+	return out
+}
+
+// showAnomalies opens a window listing the unusual days of the view's symbol, latest first
+func showAnomalies(a fyne.App, v *symbolView) {
+	if len(v.prices) == 0 {
+		v.status.Set("Fetch a symbol before listing anomalies")
+		return
+	}
+
+	table := container.NewGridWithColumns(3,
+		widget.NewLabelWithStyle(lang.L("Date"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle(lang.L("Move"), fyne.TextAlignTrailing, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle(lang.L("Z-score"), fyne.TextAlignTrailing, fyne.TextStyle{Bold: true}),
+	)
+	for i := len(v.anomalies) - 1; i >= 0; i-- {
+		an := v.anomalies[i]
+		table.Add(widget.NewLabel(shortDate(an.Date)))
+		table.Add(widget.NewLabelWithStyle(formatPercent(an.Return), fyne.TextAlignTrailing, fyne.TextStyle{}))
+		table.Add(widget.NewLabelWithStyle(fmt.Sprintf("%+.1f", an.Z), fyne.TextAlignTrailing, fyne.TextStyle{}))
+	}
+
+	note := widget.NewLabel(fmt.Sprintf(lang.L("%d days moved more than %.0f standard deviations from the previous %d days."), len(v.anomalies), anomalyThreshold, anomalyWindow))
+	w := a.NewWindow(lang.L("Anomalies") + " - " + v.symbol)
+	w.SetContent(container.NewBorder(note, nil, nil, nil, container.NewVScroll(table)))
+	w.Resize(fyne.NewSize(400, 400))
+	w.Show()
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"image/color"
+	"log"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+)
+
+// Theme choices, the system one following the desktop's dark mode
+const (
+	themeSystem = "System"
+	themeDark   = "Dark"
+	themeLight  = "Light"
+)
+
+var themeChoices = []string{themeSystem, themeDark, themeLight}
+
+// variantTheme is the default theme held to one variant
+type variantTheme struct {
+	fyne.Theme
+	variant fyne.ThemeVariant
+}
+
+// Color implements fyne.Theme
+func (t variantTheme) Color(name fyne.ThemeColorName, _ fyne.ThemeVariant) color.Color {
+	return t.Theme.Color(name, t.variant)
+}
+
+// applyTheme switches a to the theme called name. Charts take their colors
+// from it too, so they are redrawn along with the widgets
+func applyTheme(a fyne.App, name string) {
+	switch name {
+	case themeDark:
+		a.Settings().SetTheme(variantTheme{theme.DefaultTheme(), theme.VariantDark})
+	case themeLight:
+		a.Settings().SetTheme(variantTheme{theme.DefaultTheme(), theme.VariantLight})
+	default:
+		a.Settings().SetTheme(theme.DefaultTheme())
+	}
+}
+
+// themeMenu creates the submenu that picks the theme
+func themeMenu() *fyne.MenuItem {
+	menu := fyne.NewMenu(lang.L("Theme"))
+	for _, name := range themeChoices {
+		menu.Items = append(menu.Items, fyne.NewMenuItem(lang.L(name), func() {
+			s := settings.Get()
+			s.Theme = name
+			if err := settings.Set(s); err != nil {
+				log.Println("Error saving settings:", err)
+			}
+		}))
+	}
+	item := fyne.NewMenuItem(lang.L("Theme"), nil)
+	item.ChildMenu = menu
+	checkTheme(item, settings.Get().Theme)
+	return item
+}
+
+// highContrastItem creates the menu item that toggles the high contrast charts
+func highContrastItem() *fyne.MenuItem {
+	item := fyne.NewMenuItem(lang.L("High Contrast Charts"), func() {
+		s := settings.Get()
+		s.HighContrast = !s.HighContrast
+		if err := settings.Set(s); err != nil {
+			log.Println("Error saving settings:", err)
+		}
+	})
+	item.Checked = settings.Get().HighContrast
+	return item
+}
+
+// checkTheme ticks the theme called name in a theme submenu
+func checkTheme(item *fyne.MenuItem, name string) {
+	if name == "" {
+		name = themeSystem
+	}
+	for i, choice := range item.ChildMenu.Items {
+		choice.Checked = themeChoices[i] == name
+	}
+	item.ChildMenu.Refresh()
+}
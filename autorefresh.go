@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+)
+
+// refreshIntervals are the auto refresh intervals offered in the UI, in
+// minutes; any other can be set as auto_refresh_minutes in the config file
+var refreshIntervals = []int{5, 15, 60}
+
+// refreshOff is the interval label that turns auto refresh off
+const refreshOff = "Auto refresh off"
+
+// refreshLabel names an auto refresh interval of minutes
+func refreshLabel(minutes int) string {
+	if minutes <= 0 {
+		return lang.L(refreshOff)
+	}
+	return fmt.Sprintf(lang.L("Every %d min"), minutes)
+}
+
+// autoRefresh reloads the current tab every few minutes while the market is
+// open and counts down to the next reload
+type autoRefresh struct {
+	tabs      *workspace
+	attempted time.Time // last auto reload, so a failing fetch isn't retried straight away
+	interval  *widget.Select
+	countdown *widget.Label
+	content   fyne.CanvasObject
+}
+
+// newAutoRefresh creates the interval picker and countdown and starts the timer
+func newAutoRefresh(tabs *workspace) *autoRefresh {
+	r := &autoRefresh{tabs: tabs, countdown: widget.NewLabel("")}
+	var labels []string
+	for _, minutes := range append([]int{0}, refreshIntervals...) {
+		labels = append(labels, refreshLabel(minutes))
+	}
+	if label := refreshLabel(settings.Get().AutoRefreshMinutes); !slices.Contains(labels, label) {
+		labels = append(labels, label)
+	}
+	r.interval = widget.NewSelect(labels, func(label string) {
+		s := settings.Get()
+		if refreshLabel(s.AutoRefreshMinutes) == label {
+			return
+		}
+		s.AutoRefreshMinutes = 0
+		for _, minutes := range refreshIntervals {
+			if refreshLabel(minutes) == label {
+				s.AutoRefreshMinutes = minutes
+			}
+		}
+		if err := settings.Set(s); err != nil {
+			log.Println("Error saving settings:", err)
+		}
+		r.tick(time.Now())
+	})
+	r.SetMinutes(settings.Get().AutoRefreshMinutes)
+	r.content = container.NewHBox(r.countdown, r.interval)
+
+	go func() {
+		for now := range time.Tick(time.Second) {
+			r.tick(now)
+		}
+	}()
+	return r
+}
+
+// SetMinutes shows the interval picked in the settings
+func (r *autoRefresh) SetMinutes(minutes int) {
+	r.interval.SetSelected(refreshLabel(minutes))
+}
+
+// tick reloads the current tab once the interval has passed since it was
+// last fetched and updates the countdown
+func (r *autoRefresh) tick(now time.Time) {
+	interval := time.Duration(settings.Get().AutoRefreshMinutes) * time.Minute
+	v := r.tabs.Current()
+	switch {
+	case interval <= 0 || v == nil || v.symbol == "":
+		r.countdown.SetText("")
+		return
+	case !marketOpen(now):
+		r.countdown.SetText(lang.L("Paused while the market is closed"))
+		return
+	case v.busy:
+		r.countdown.SetText(lang.L("Refreshing..."))
+		return
+	}
+
+	last := v.fetchedAt
+	if r.attempted.After(last) {
+		last = r.attempted
+	}
+	left := last.Add(interval).Sub(now)
+	if left <= 0 {
+		r.attempted = now
+		v.Load(v.symbol)
+		r.countdown.SetText(lang.L("Refreshing..."))
+		return
+	}
+	left = left.Round(time.Second)
+	r.countdown.SetText(fmt.Sprintf(lang.L("Refresh in %d:%02d"), int(left.Minutes()), int(left.Seconds())%60))
+}
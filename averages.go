@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"math"
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/lang"
+)
+
+// Moving average kinds
+const (
+	averageSMA = "SMA"
+	averageEMA = "EMA"
+)
+
+// averagePeriods are the moving average lengths offered, in bars
+var averagePeriods = []int{20, 50, 200}
+
+// averageColors tells the periods apart, the EMA of a period is drawn dashed
+// in the same color as its SMA
+var averageColors = map[int]color.Color{
+	20:  color.RGBA{R: 255, G: 140, A: 255},
+	50:  color.RGBA{G: 110, B: 220, A: 255},
+	200: color.RGBA{R: 150, G: 60, B: 200, A: 255},
+}
+
+// bollingerColor shades the area between the Bollinger Bands
+var bollingerColor = color.NRGBA{R: 90, G: 90, B: 160, A: 40}
+
+// bollingerLabel names the Bollinger Bands item of the averages menu
+const bollingerLabel = "Bollinger Bands"
+
+// averageLine is a moving average to draw over the price history
+type averageLine struct {
+	Label  string
+	Kind   string
+	Period int
+	Start  int // first bar with a full window
+	Values []float64
+}
+
+// averageLabel names a moving average the way it is stored in the settings, e.g. "SMA 50"
+func averageLabel(kind string, period int) string {
+	return fmt.Sprintf("%s %d", kind, period)
+}
+
+// sma returns the simple moving average of prices over n bars, starting at bar n-1
+func sma(prices []float64, n int) []float64 {
+	if n < 1 || len(prices) < n {
+		return nil
+	}
+	out := make([]float64, 0, len(prices)-n+1)
+	sum := 0.0
+	for i, p := range prices {
+		sum += p
+		if i >= n {
+			sum -= prices[i-n]
+		}
+		if i >= n-1 {
+			out = append(out, sum/float64(n))
+		}
+	}
+	return out
+}
+
+// ema returns the exponential moving average of prices over n bars, seeded
+// with the SMA of the first n bars and starting at bar n-1
+func ema(prices []float64, n int) []float64 {
+	out := sma(prices[:min(n, len(prices))], n)
+	if out == nil {
+		return nil
+	}
+	k := 2 / float64(n+1)
+	for _, p := range prices[n:] {
+		out = append(out, p*k+out[len(out)-1]*(1-k))
+	}
+	return out
+}
+
+// bollingerBands is the range of k standard deviations around an n bar SMA
+type bollingerBands struct {
+	Label         string
+	Start         int // first bar with a full window
+	Lower, Middle []float64
+	Upper         []float64
+}
+
+// bollinger computes the Bollinger Bands of prices, or returns nil if the
+// history is shorter than the period
+func bollinger(prices []float64, b Bollinger) *bollingerBands {
+	middle := sma(prices, b.Period)
+	if middle == nil {
+		return nil
+	}
+	bands := &bollingerBands{
+		Label:  fmt.Sprintf("Bollinger %d, %gσ", b.Period, b.Deviations),
+		Start:  b.Period - 1,
+		Middle: middle,
+		Lower:  make([]float64, len(middle)),
+		Upper:  make([]float64, len(middle)),
+	}
+	for i, m := range middle {
+		variance := 0.0
+		for _, p := range prices[i : i+b.Period] {
+			variance += (p - m) * (p - m)
+		}
+		width := b.Deviations * math.Sqrt(variance/float64(b.Period))
+		bands.Lower[i], bands.Upper[i] = m-width, m+width
+	}
+	return bands
+}
+
+// movingAverages computes the averages named in labels, skipping those longer than the history
+func movingAverages(prices []float64, labels []string) []averageLine {
+	var lines []averageLine
+	for _, kind := range []string{averageSMA, averageEMA} {
+		for _, n := range averagePeriods {
+			label := averageLabel(kind, n)
+			if !slices.Contains(labels, label) {
+				continue
+			}
+			values := sma(prices, n)
+			if kind == averageEMA {
+				values = ema(prices, n)
+			}
+			if values != nil {
+				lines = append(lines, averageLine{Label: label, Kind: kind, Period: n, Start: n - 1, Values: values})
+			}
+		}
+	}
+	return lines
+}
+
+// averagesMenu builds a menu with a checkable item per moving average, toggling
+// one saves it to the settings
+func averagesMenu() *fyne.Menu {
+	menu := fyne.NewMenu(lang.L("Averages"))
+	for _, kind := range []string{averageSMA, averageEMA} {
+		for _, n := range averagePeriods {
+			label := averageLabel(kind, n)
+			item := fyne.NewMenuItem(label, func() {
+				s := settings.Get()
+				if i := slices.Index(s.MovingAverages, label); i >= 0 {
+					s.MovingAverages = slices.Delete(slices.Clone(s.MovingAverages), i, i+1)
+				} else {
+					s.MovingAverages = append(slices.Clone(s.MovingAverages), label)
+				}
+				if err := settings.Set(s); err != nil {
+					log.Println("Error saving settings:", err)
+				}
+			})
+			item.Checked = slices.Contains(settings.Get().MovingAverages, label)
+			menu.Items = append(menu.Items, item)
+		}
+	}
+
+	bands := fyne.NewMenuItem(lang.L(bollingerLabel), func() {
+		s := settings.Get()
+		s.Bollinger.Show = !s.Bollinger.Show
+		if err := settings.Set(s); err != nil {
+			log.Println("Error saving settings:", err)
+		}
+	})
+	bands.Checked = settings.Get().Bollinger.Show
+	menu.Items = append(menu.Items, fyne.NewMenuItemSeparator(), bands)
+	return menu
+}
+
+// overlaysChanged reports whether the lines drawn over the price differ between a and b
+func overlaysChanged(a, b Settings) bool {
+	return !slices.Equal(a.MovingAverages, b.MovingAverages) || a.Bollinger != b.Bollinger
+}
+
+// checkAverages updates the checks of an averages menu from s
+func checkAverages(menu *fyne.Menu, s Settings) {
+	for _, item := range menu.Items {
+		if item.Label == lang.L(bollingerLabel) {
+			item.Checked = s.Bollinger.Show
+		} else {
+			item.Checked = slices.Contains(s.MovingAverages, item.Label)
+		}
+	}
+	menu.Refresh()
+}
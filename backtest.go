@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+	"gomarket/forecast"
+)
+
+// Bars each backtest window is fitted on
+const backtestWindow = 120
+
+// backtestResult is the accuracy of one model in a walk-forward backtest
+type backtestResult struct {
+	Model    string
+	Accuracy forecast.Accuracy
+	Err      error
+}
+
+// runBacktest scores every selectable model on the prices with a walk-forward backtest
+func runBacktest(prices []float64, horizon int) []backtestResult {
+	results := make([]backtestResult, 0, len(forecastModels))
+	for _, name := range forecastModels {
+		acc, err := forecast.WalkForward(prices, func() forecast.Forecaster { return preprocess(newForecaster(name), settings.Get().Preprocessing) }, backtestWindow, horizon, horizon)
+		results = append(results, backtestResult{Model: name, Accuracy: acc, Err: err})
+	}
+
+	// Best first, failed models last
+	sort.SliceStable(results, func(i, j int) bool {
+		if (results[i].Err == nil) != (results[j].Err == nil) {
+			return results[i].Err == nil
+		}
+		return results[i].Accuracy.RMSE < results[j].Accuracy.RMSE
+	})
+	return results
+}
+
+// showBacktest opens a window comparing the walk-forward accuracy of every model on the view's symbol
+func showBacktest(a fyne.App, v *symbolView) {
+	if len(v.prices) == 0 {
+		v.status.Set("Fetch a symbol before running a backtest")
+		return
+	}
+	prices, symbol := v.prices, v.symbol
+
+	table := container.NewGridWithColumns(4)
+	run := func(horizon int) {
+		v.status.Set("Backtesting %d models on %s...", len(forecastModels), symbol)
+		go func() {
+			results := runBacktest(prices, horizon)
+
+			cells := []fyne.CanvasObject{
+				widget.NewLabelWithStyle(lang.L("Model"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				widget.NewLabelWithStyle("MAPE", fyne.TextAlignTrailing, fyne.TextStyle{Bold: true}),
+				widget.NewLabelWithStyle("RMSE", fyne.TextAlignTrailing, fyne.TextStyle{Bold: true}),
+				widget.NewLabelWithStyle(lang.L("Windows"), fyne.TextAlignTrailing, fyne.TextStyle{Bold: true}),
+			}
+			for _, r := range results {
+				mape, rmse := "-", "-"
+				if r.Err == nil {
+					mape = formatNumber(r.Accuracy.MAPE, 2) + "%"
+					rmse = formatNumber(r.Accuracy.RMSE, 2)
+				}
+				cells = append(cells,
+					widget.NewLabel(r.Model),
+					widget.NewLabelWithStyle(mape, fyne.TextAlignTrailing, fyne.TextStyle{}),
+					widget.NewLabelWithStyle(rmse, fyne.TextAlignTrailing, fyne.TextStyle{}),
+					widget.NewLabelWithStyle(fmt.Sprint(r.Accuracy.Folds), fyne.TextAlignTrailing, fyne.TextStyle{}),
+				)
+			}
+			table.Objects = cells
+			table.Refresh()
+			v.status.Set("Backtested %s over %d day horizons, best model %s", symbol, horizon, results[0].Model)
+		}()
+	}
+
+	labels := make([]string, len(forecastHorizons))
+	for i, days := range forecastHorizons {
+		labels[i] = fmt.Sprintf(lang.L("%d days"), days)
+	}
+	horizonSelect := widget.NewSelect(labels, func(label string) {
+		if i := slices.Index(labels, label); i >= 0 {
+			run(forecastHorizons[i])
+		}
+	})
+
+	w := a.NewWindow(lang.L("Backtest") + " - " + symbol)
+	note := widget.NewLabel(fmt.Sprintf(lang.L("Each model is refitted on a rolling %d day window and scored on the days that follow."), backtestWindow))
+	w.SetContent(container.NewBorder(container.NewVBox(note, horizonSelect), nil, nil, nil, container.NewVScroll(table)))
+	w.Resize(fyne.NewSize(600, 400))
+	horizonSelect.SetSelected(labels[0])
+	w.Show()
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"fyne.io/fyne/v2/lang"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// benchmarkColor draws the benchmark overlay
+var benchmarkColor = color.RGBA{R: 120, G: 160, B: 255, A: 255}
+
+// scaledBenchmark returns the benchmark scaled to the symbol's price at the
+// first bar from start, so both lines leave from the same point and the gap
+// between them is the symbol's performance relative to the benchmark
+func scaledBenchmark(c chartData, start float64) []float64 {
+	if len(c.Benchmark) != len(c.Prices) || len(c.Prices) == 0 {
+		return nil
+	}
+	first := min(max(int(math.Ceil(start)), 0), len(c.Prices)-1)
+	if c.Benchmark[first] == 0 {
+		return nil
+	}
+	k := c.Prices[first] / c.Benchmark[first]
+	out := make([]float64, len(c.Benchmark))
+	for i, v := range c.Benchmark {
+		out[i] = v * k
+	}
+	return out
+}
+
+// addBenchmark draws the benchmark of c onto p, rebased at start
+func addBenchmark(p *plot.Plot, c chartData, start float64) error {
+	values := scaledBenchmark(c, start)
+	if values == nil {
+		return nil
+	}
+	points := make(plotter.XYs, len(values))
+	for i, v := range values {
+		points[i].X = float64(i)
+		points[i].Y = v
+	}
+	line, err := plotter.NewLine(points)
+	if err != nil {
+		return err
+	}
+	line.Color = benchmarkColor
+	line.Width = vg.Points(1)
+	p.Add(line)
+	p.Legend.Add(fmt.Sprintf(lang.L("%s (rebased)"), c.BenchmarkSymbol), line)
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"sync"
+
+	"gomarket/forecast"
+)
+
+// forecastKey identifies a forecast by the data it was fitted on and the model settings
+type forecastKey struct {
+	Symbol string
+	Data   uint64 // hash of the prices
+	Model  string
+	Params string
+}
+
+// forecastResult is a cached forecast along with the fitted model behind it
+type forecastResult struct {
+	Predictions []float64
+	Model       forecast.Forecaster
+}
+
+// forecastCache keeps fitted forecasts so fetching unchanged data again skips the model fit
+type forecastCache struct {
+	mu      sync.Mutex
+	entries map[forecastKey]forecastResult
+}
+
+// forecasts is the cache shared by every symbol view
+var forecasts = &forecastCache{entries: make(map[forecastKey]forecastResult)}
+
+// Get returns the cached forecast for k, if any
+func (c *forecastCache) Get(k forecastKey) (forecastResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, ok := c.entries[k]
+	return r, ok
+}
+
+// Put stores a forecast and drops those fitted on older data for the same symbol
+func (c *forecastCache) Put(k forecastKey, r forecastResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for old := range c.entries {
+		if old.Symbol == k.Symbol && old.Data != k.Data {
+			delete(c.entries, old)
+		}
+	}
+	c.entries[k] = r
+}
+
+// hashPrices returns an FNV-1a hash of the prices
+func hashPrices(prices []float64) uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	for _, p := range prices {
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(p))
+		h.Write(buf[:])
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Chart styles for the price history
+const (
+	styleLine    = "Line"
+	styleCandles = "Candles"
+)
+
+var chartStyles = []string{styleLine, styleCandles}
+
+// Candle colors for up and down days
+var (
+	candleUp   = color.RGBA{G: 160, A: 255}
+	candleDown = color.RGBA{R: 220, A: 255}
+)
+
+// candlesticks is a plotter drawing OHLC bars as candles, green when the close
+// is at or above the open and red when below
+type candlesticks struct {
+	bars []StockData
+	x0   float64 // x of the first bar, one unit per bar after it
+}
+
+// hasOHLC reports whether every bar has its open, high and low
+func hasOHLC(bars []StockData) bool {
+	for _, b := range bars {
+		if b.Open == 0 || b.High == 0 || b.Low == 0 {
+			return false
+		}
+	}
+	return len(bars) > 0
+}
+
+// Plot implements plot.Plotter
+func (c candlesticks) Plot(canvas draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&canvas)
+	width := (trX(1) - trX(0)) * 0.6
+	if width < vg.Points(1) {
+		width = vg.Points(1)
+	}
+
+	for i, b := range c.bars {
+		col := candleUp
+		if b.Close < b.Open {
+			col = candleDown
+		}
+		x := trX(c.x0 + float64(i))
+		if x < canvas.Min.X || x > canvas.Max.X {
+			continue
+		}
+		wick := draw.LineStyle{Color: col, Width: vg.Points(0.75)}
+		canvas.StrokeLine2(wick, x, trY(b.Low), x, trY(b.High))
+
+		bottom, top := trY(math.Min(b.Open, b.Close)), trY(math.Max(b.Open, b.Close))
+		if top-bottom < vg.Points(0.5) {
+			top = bottom + vg.Points(0.5) // keep doji days visible
+		}
+		canvas.FillPolygon(col, []vg.Point{
+			{X: x - width/2, Y: bottom},
+			{X: x + width/2, Y: bottom},
+			{X: x + width/2, Y: top},
+			{X: x - width/2, Y: top},
+		})
+	}
+}
+
+// DataRange implements plot.DataRanger
+func (c candlesticks) DataRange() (xmin, xmax, ymin, ymax float64) {
+	ymin, ymax = math.Inf(1), math.Inf(-1)
+	for _, b := range c.bars {
+		ymin = math.Min(ymin, b.Low)
+		ymax = math.Max(ymax, b.High)
+	}
+	return c.x0 - 0.5, c.x0 + float64(len(c.bars)) - 0.5, ymin, ymax
+}
+
+// Thumbnail implements plot.Thumbnailer for the legend
+func (c candlesticks) Thumbnail(canvas *draw.Canvas) {
+	r := canvas.Rectangle
+	mid := (r.Min.X + r.Max.X) / 2
+	w := (r.Max.X - r.Min.X) / 4
+	canvas.FillPolygon(candleUp, []vg.Point{
+		{X: mid - w, Y: r.Min.Y},
+		{X: mid + w, Y: r.Min.Y},
+		{X: mid + w, Y: r.Max.Y},
+		{X: mid - w, Y: r.Max.Y},
+	})
+}
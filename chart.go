@@ -0,0 +1,586 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"log"
+	"math"
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// minVisibleBars is the closest the chart can be zoomed in
+const minVisibleBars = 10
+
+// panelShare is the part of the chart height given to each panel below the
+// price, less when there are several
+func panelShare(panels int) float64 {
+	if panels > 1 {
+		return 0.2
+	}
+	return 0.25
+}
+
+// drawChart draws the price plot, and the panels such as volume below it,
+// onto dc and returns the area the price data was drawn in
+func drawChart(price *plot.Plot, panels []*plot.Plot, dc draw.Canvas) vg.Rectangle {
+	if len(panels) == 0 {
+		price.Draw(dc)
+		return price.DataCanvas(dc).Rectangle
+	}
+
+	plots := [][]*plot.Plot{{price}}
+	for _, p := range panels {
+		plots = append(plots, []*plot.Plot{p})
+	}
+	tiles := draw.Tiles{Rows: len(plots), Cols: 1, PadY: vg.Points(4)}
+	canvases := plot.Align(plots, tiles, dc)
+
+	// Align splits the height evenly, give the price plot most of it
+	top, bottom := canvases[0][0].Max.Y, canvases[len(plots)-1][0].Min.Y
+	height := (top - bottom) * vg.Length(panelShare(len(panels)))
+	for i := len(plots) - 1; i > 0; i-- {
+		canvases[i][0].Min.Y = bottom
+		canvases[i][0].Max.Y = bottom + height - tiles.PadY
+		bottom += height
+	}
+	canvases[0][0].Min.Y = bottom
+	for i, p := range plots {
+		p[0].Draw(canvases[i][0])
+	}
+	return price.DataCanvas(canvases[0][0]).Rectangle
+}
+
+// chartFrame records where the data of a rendered chart ended up, for mapping
+// mouse positions back to bars and prices
+type chartFrame struct {
+	area          vg.Rectangle // price data area in points from the bottom left
+	width, height vg.Length    // size of the whole image
+	xmin, xmax    float64
+	ymin, ymax    float64
+	logY          bool
+}
+
+// chartWidget shows a chartData that can be zoomed with the mouse wheel,
+// panned by dragging and reset by double tapping, with a crosshair readout.
+// It takes keyboard focus too, see TypedKey
+type chartWidget struct {
+	widget.BaseWidget
+
+	data       chartData
+	start, end float64 // visible bars counted from the first price
+	zoomed     bool    // the user moved away from the default range
+	focused    bool
+	cursor     int // bar under the crosshair while the chart has keyboard focus
+	frame      chartFrame
+	rendered   fyne.Size
+	onError    func(error)
+	onAnnotate func([]Annotation)       // called with the chart's annotations after the user edits them
+	onRange    func(start, end float64) // called when the user zooms or pans
+
+	image    *canvas.Image
+	vline    *canvas.Line
+	hline    *canvas.Line
+	readout  *canvas.Text
+	fallback *widget.Label // the numbers, shown when the chart can't be drawn
+}
+
+// newChartWidget creates an empty chart
+func newChartWidget() *chartWidget {
+	c := &chartWidget{
+		image:    canvas.NewImageFromImage(nil),
+		vline:    canvas.NewLine(theme.ForegroundColor()),
+		hline:    canvas.NewLine(theme.ForegroundColor()),
+		readout:  canvas.NewText("", theme.ForegroundColor()),
+		fallback: widget.NewLabel(""),
+	}
+	c.image.FillMode = canvas.ImageFillStretch
+	for _, l := range []*canvas.Line{c.vline, c.hline} {
+		l.StrokeColor = color.NRGBA{R: 128, G: 128, B: 128, A: 160}
+		l.StrokeWidth = 1
+		l.Hide()
+	}
+	c.readout.TextStyle.Monospace = true
+	c.readout.Hide()
+	c.fallback.TextStyle.Monospace = true
+	c.fallback.Hide()
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+// SetData shows d, keeping the zoom while the symbol stays the same
+func (c *chartWidget) SetData(d chartData) {
+	if d.Symbol != c.data.Symbol {
+		c.zoomed = false
+	}
+	c.data = d
+	if c.zoomed {
+		c.setRange(c.start, c.end)
+	} else {
+		c.start, c.end = defaultRange(d)
+	}
+	c.Refresh()
+}
+
+// SetWindow shows the last days trading days, or all of them for 0, undoing any zoom
+func (c *chartWidget) SetWindow(days int) {
+	c.data.WindowDays = days
+	c.zoomed = false
+	c.start, c.end = defaultRange(c.data)
+	c.Refresh()
+}
+
+// SetDrawdown shows or hides the drawdown panel
+func (c *chartWidget) SetDrawdown(on bool) {
+	if c.data.Drawdown != on {
+		c.data.Drawdown = on
+		c.Refresh()
+	}
+}
+
+// SetLogScale switches the price axis between linear and logarithmic
+func (c *chartWidget) SetLogScale(on bool) {
+	if c.data.LogScale != on {
+		c.data.LogScale = on
+		c.Refresh()
+	}
+}
+
+// SetPercent switches the price axis between prices and percent return
+func (c *chartWidget) SetPercent(on bool) {
+	if c.data.Percent != on {
+		c.data.Percent = on
+		c.Refresh()
+	}
+}
+
+// CreateRenderer implements fyne.Widget
+func (c *chartWidget) CreateRenderer() fyne.WidgetRenderer {
+	return &chartRenderer{chart: c, objects: []fyne.CanvasObject{c.image, c.fallback, c.vline, c.hline, c.readout}}
+}
+
+// render draws the chart at size and swaps it into the image, listing the
+// numbers instead if the chart can't be drawn
+func (c *chartWidget) render(size fyne.Size) {
+	c.rendered = size
+	c.fallback.Hide()
+	c.readout.Color = theme.ForegroundColor()
+	if size.Width < 1 || size.Height < 1 || len(c.data.Prices) == 0 {
+		c.image.Image = nil
+		c.image.Refresh()
+		return
+	}
+
+	img, frame, err := c.draw(size)
+	if err != nil {
+		log.Println("Error plotting data:", err)
+		if c.onError != nil {
+			c.onError(err)
+		}
+		c.frame = chartFrame{}
+		c.image.Image = nil
+		c.image.Refresh()
+		c.fallback.SetText(fallbackText(fmt.Sprintf(lang.L("Chart unavailable, latest closes of %s"), c.data.Symbol), c.data.Prices, c.barDate))
+		c.fallback.Show()
+		return
+	}
+	c.frame = frame
+	c.image.Image = img
+	c.image.Refresh()
+}
+
+// draw renders the chart at size, turning a panic in the plotting code into an error
+func (c *chartWidget) draw(size fyne.Size) (img image.Image, frame chartFrame, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("rendering chart: %v", r)
+		}
+	}()
+
+	price, panels, err := chartPlots(c.data, c.start, c.end)
+	if err != nil {
+		return nil, chartFrame{}, err
+	}
+
+	// One point per Fyne unit keeps the chart text close to the UI text size,
+	// and one pixel per screen pixel keeps it sharp
+	w, h := vg.Length(size.Width), vg.Length(size.Height)
+	out := newChartCanvas(w, h, displayDPI(c))
+	area := drawChart(price, panels, draw.New(out))
+	_, logY := price.Y.Scale.(logScale)
+	frame = chartFrame{area: area, width: w, height: h, xmin: price.X.Min, xmax: price.X.Max, ymin: price.Y.Min, ymax: price.Y.Max, logY: logY}
+	return out.Image(), frame, nil
+}
+
+// setRange shows bars start to end, kept within the data and no closer than minVisibleBars
+func (c *chartWidget) setRange(start, end float64) {
+	lo, hi := -0.5, float64(len(c.data.Prices)+len(c.data.Predictions))-0.5
+	span := math.Min(math.Max(end-start, minVisibleBars), hi-lo)
+	start = math.Max(lo, math.Min(start, hi-span))
+	c.start, c.end = start, start+span
+}
+
+// toData converts a position on the widget to a bar and price, reporting
+// whether it is inside the price data area
+func (c *chartWidget) toData(pos fyne.Position) (x, y float64, ok bool) {
+	f, size := c.frame, c.Size()
+	if size.Width == 0 || size.Height == 0 || f.width == 0 {
+		return 0, 0, false
+	}
+	px := vg.Length(pos.X/size.Width) * f.width
+	py := vg.Length(1-pos.Y/size.Height) * f.height
+	a := f.area
+	if px < a.Min.X || px > a.Max.X || py < a.Min.Y || py > a.Max.Y {
+		return 0, 0, false
+	}
+	x = f.xmin + float64((px-a.Min.X)/(a.Max.X-a.Min.X))*(f.xmax-f.xmin)
+	fy := float64((py - a.Min.Y) / (a.Max.Y - a.Min.Y))
+	y = f.ymin + fy*(f.ymax-f.ymin)
+	if f.logY {
+		y = f.ymin * math.Pow(f.ymax/f.ymin, fy)
+	}
+	return x, y, true
+}
+
+// dataArea returns the price data area in widget coordinates
+func (c *chartWidget) dataArea() (fyne.Position, fyne.Position) {
+	f, size := c.frame, c.Size()
+	if f.width == 0 {
+		return fyne.Position{}, fyne.Position{}
+	}
+	toX := func(v vg.Length) float32 { return float32(v/f.width) * size.Width }
+	toY := func(v vg.Length) float32 { return (1 - float32(v/f.height)) * size.Height }
+	return fyne.NewPos(toX(f.area.Min.X), toY(f.area.Max.Y)), fyne.NewPos(toX(f.area.Max.X), toY(f.area.Min.Y))
+}
+
+// describe returns the readout for the bar under the mouse: its date and
+// close, or in the forecast region the predicted value, falling back to the
+// price at the crosshair. In percent mode the return is added to the price
+func (c *chartWidget) describe(x, y float64) string {
+	i := int(math.Round(x))
+	prices, predictions := c.data.Prices, c.data.Predictions
+	cur := symbolCurrency(c.data.Symbol)
+	value := func(v float64) string {
+		if base, ok := returnBase(c.data, c.start); c.data.Percent && ok {
+			return formatPrice(v, cur) + " (" + formatPercent(percentReturn(v, base)) + ")"
+		}
+		return formatPrice(v, cur)
+	}
+	switch {
+	case i >= 0 && i < len(prices):
+		return fmt.Sprintf(lang.L("%s  Close %s"), c.barDate(i), value(prices[i]))
+	case i >= len(prices) && i < len(prices)+len(predictions):
+		return fmt.Sprintf(lang.L("%s  Predicted %s"), c.barDate(i), value(predictions[i-len(prices)]))
+	default:
+		return value(y)
+	}
+}
+
+// barDate returns the date of bar i, marking projected forecast dates with a ~
+func (c *chartWidget) barDate(i int) string {
+	t, ok := barTime(c.data.Dates, c.data.BarDays, i)
+	switch {
+	case !ok || len(c.data.Dates) != len(c.data.Prices):
+		return fmt.Sprintf(lang.L("Day %d"), i)
+	case i >= len(c.data.Dates):
+		return "~" + formatDate(t, "2006-01-02")
+	default:
+		return formatDate(t, "2006-01-02")
+	}
+}
+
+// SetAnnotations redraws the chart with list as its annotations
+func (c *chartWidget) SetAnnotations(list []Annotation) {
+	c.data.Annotations = list
+	c.Refresh()
+}
+
+// TappedSecondary offers to pin a note or price level to the bar under the mouse
+func (c *chartWidget) TappedSecondary(ev *fyne.PointEvent) {
+	x, y, ok := c.toData(ev.Position)
+	i := int(math.Round(x))
+	if !ok || i < 0 || i >= len(c.data.Prices) || len(c.data.Dates) != len(c.data.Prices) {
+		return
+	}
+	cnv := fyne.CurrentApp().Driver().CanvasForObject(c)
+	if cnv == nil {
+		return
+	}
+
+	add := func(a Annotation) {
+		c.data.Annotations = append(slices.Clone(c.data.Annotations), a)
+		c.Refresh()
+		if c.onAnnotate != nil {
+			c.onAnnotate(c.data.Annotations)
+		}
+	}
+	at := Annotation{Date: c.data.Dates[i], Price: y}
+	menu := fyne.NewMenu("",
+		fyne.NewMenuItem(lang.L("Add Note Here..."), func() {
+			at.Kind = annotationNote
+			askNote(cnv, at, add)
+		}),
+		fyne.NewMenuItem(fmt.Sprintf(lang.L("Add Price Level at %s"), formatPrice(y, symbolCurrency(c.data.Symbol))), func() {
+			at.Kind = annotationLevel
+			add(at)
+		}),
+	)
+	if len(c.data.Annotations) > 0 {
+		menu.Items = append(menu.Items, fyne.NewMenuItemSeparator(), fyne.NewMenuItem(lang.L("Clear Annotations"), func() {
+			c.SetAnnotations(nil)
+			if c.onAnnotate != nil {
+				c.onAnnotate(nil)
+			}
+		}))
+	}
+	widget.ShowPopUpMenuAtPosition(menu, cnv, ev.AbsolutePosition)
+}
+
+// Scrolled zooms around the bar under the mouse
+func (c *chartWidget) Scrolled(ev *fyne.ScrollEvent) {
+	x, _, ok := c.toData(ev.Position)
+	if !ok {
+		x = (c.start + c.end) / 2
+	}
+	factor := 0.8
+	if ev.Scrolled.DY < 0 {
+		factor = 1 / factor
+	}
+	c.zoomTo(x-(x-c.start)*factor, x+(c.end-x)*factor)
+}
+
+// Dragged pans the chart along with the mouse
+func (c *chartWidget) Dragged(ev *fyne.DragEvent) {
+	tl, br := c.dataArea()
+	if br.X <= tl.X {
+		return
+	}
+	shift := -float64(ev.Dragged.DX/(br.X-tl.X)) * (c.end - c.start)
+	c.zoomTo(c.start+shift, c.end+shift)
+	c.MouseMoved(&desktop.MouseEvent{PointEvent: ev.PointEvent})
+}
+
+// DragEnd implements fyne.Draggable
+func (c *chartWidget) DragEnd() {}
+
+// DoubleTapped goes back to the default range
+func (c *chartWidget) DoubleTapped(*fyne.PointEvent) {
+	c.zoomed = false
+	c.start, c.end = defaultRange(c.data)
+	c.Refresh()
+	if c.onRange != nil {
+		c.onRange(c.start, c.end)
+	}
+}
+
+// zoomTo shows bars start to end at the user's request
+func (c *chartWidget) zoomTo(start, end float64) {
+	c.zoomed = true
+	c.setRange(start, end)
+	c.Refresh()
+	if c.onRange != nil {
+		c.onRange(c.start, c.end)
+	}
+}
+
+// SetRange shows bars start to end, for keeping charts in step
+func (c *chartWidget) SetRange(start, end float64) {
+	if start == c.start && end == c.end {
+		return
+	}
+	c.zoomed = true
+	c.setRange(start, end)
+	c.Refresh()
+}
+
+// FocusGained puts the crosshair on the last visible bar
+func (c *chartWidget) FocusGained() {
+	c.focused = true
+	c.cursor = int(math.Floor(c.end))
+	c.moveCursor(0)
+}
+
+// FocusLost hides the crosshair
+func (c *chartWidget) FocusLost() {
+	c.focused = false
+	c.MouseOut()
+}
+
+// TypedRune zooms in on + and out on -
+func (c *chartWidget) TypedRune(r rune) {
+	switch r {
+	case '+', '=':
+		c.zoomKeys(0.8)
+	case '-':
+		c.zoomKeys(1 / 0.8)
+	}
+}
+
+// TypedKey moves the crosshair a bar with Left and Right, a screen with Page
+// Up and Page Down, panning to keep it in view, zooms with Up and Down and
+// goes back to the default range with Home
+func (c *chartWidget) TypedKey(ev *fyne.KeyEvent) {
+	switch ev.Name {
+	case fyne.KeyLeft:
+		c.moveCursor(-1)
+	case fyne.KeyRight:
+		c.moveCursor(1)
+	case fyne.KeyPageUp:
+		c.moveCursor(-int(c.end - c.start))
+	case fyne.KeyPageDown:
+		c.moveCursor(int(c.end - c.start))
+	case fyne.KeyUp:
+		c.zoomKeys(0.8)
+	case fyne.KeyDown:
+		c.zoomKeys(1 / 0.8)
+	case fyne.KeyHome:
+		c.DoubleTapped(nil)
+		c.cursor = int(math.Floor(c.end))
+		c.moveCursor(0)
+	}
+}
+
+// zoomKeys zooms around the crosshair by factor
+func (c *chartWidget) zoomKeys(factor float64) {
+	x := float64(c.cursor)
+	c.zoomTo(x-(x-c.start)*factor, x+(c.end-x)*factor)
+	c.moveCursor(0)
+}
+
+// moveCursor moves the keyboard crosshair by n bars, panning the chart when
+// it leaves the visible range
+func (c *chartWidget) moveCursor(n int) {
+	last := len(c.data.Prices) + len(c.data.Predictions) - 1
+	if last < 0 {
+		c.hideCrosshair()
+		return
+	}
+	c.cursor = max(0, min(c.cursor+n, last))
+	x := float64(c.cursor)
+	if x < c.start || x > c.end {
+		shift := x - c.start - 0.5
+		if x > c.end {
+			shift = x - c.end + 0.5
+		}
+		c.zoomTo(c.start+shift, c.end+shift)
+	}
+
+	y := c.barValue(c.cursor)
+	pos, ok := c.toPosition(x, y)
+	if !ok {
+		c.hideCrosshair()
+		return
+	}
+	c.showCrosshair(pos, c.describe(x, y))
+}
+
+// barValue returns the close of bar i, or its prediction in the forecast region
+func (c *chartWidget) barValue(i int) float64 {
+	if i < len(c.data.Prices) {
+		return c.data.Prices[i]
+	}
+	return c.data.Predictions[i-len(c.data.Prices)]
+}
+
+// toPosition converts a bar and price to a position on the widget, the
+// reverse of toData
+func (c *chartWidget) toPosition(x, y float64) (fyne.Position, bool) {
+	f, size := c.frame, c.Size()
+	if f.width == 0 || f.xmax == f.xmin || f.ymax == f.ymin {
+		return fyne.Position{}, false
+	}
+	fy := (y - f.ymin) / (f.ymax - f.ymin)
+	if f.logY {
+		fy = math.Log(y/f.ymin) / math.Log(f.ymax/f.ymin)
+	}
+	fy = math.Max(0, math.Min(fy, 1))
+	a := f.area
+	px := a.Min.X + vg.Length((x-f.xmin)/(f.xmax-f.xmin))*(a.Max.X-a.Min.X)
+	py := a.Min.Y + vg.Length(fy)*(a.Max.Y-a.Min.Y)
+	return fyne.NewPos(float32(px/f.width)*size.Width, (1-float32(py/f.height))*size.Height), true
+}
+
+// MouseIn implements desktop.Hoverable
+func (c *chartWidget) MouseIn(ev *desktop.MouseEvent) {
+	c.MouseMoved(ev)
+}
+
+// MouseMoved moves the crosshair and its readout to the mouse
+func (c *chartWidget) MouseMoved(ev *desktop.MouseEvent) {
+	x, y, ok := c.toData(ev.Position)
+	if !ok || len(c.data.Prices) == 0 {
+		c.MouseOut()
+		return
+	}
+
+	c.showCrosshair(ev.Position, c.describe(x, y))
+}
+
+// showCrosshair draws the crosshair through pos with text beside it
+func (c *chartWidget) showCrosshair(pos fyne.Position, text string) {
+	tl, br := c.dataArea()
+	c.vline.Position1, c.vline.Position2 = fyne.NewPos(pos.X, tl.Y), fyne.NewPos(pos.X, br.Y)
+	c.hline.Position1, c.hline.Position2 = fyne.NewPos(tl.X, pos.Y), fyne.NewPos(br.X, pos.Y)
+	c.readout.Text = text
+	c.readout.Move(pos.Add(fyne.NewPos(8, -c.readout.MinSize().Height-4)))
+	c.readout.Resize(c.readout.MinSize())
+	for _, o := range []fyne.CanvasObject{c.vline, c.hline, c.readout} {
+		o.Show()
+		o.Refresh()
+	}
+}
+
+// MouseOut hides the crosshair, or puts it back on the keyboard's bar while
+// the chart has focus
+func (c *chartWidget) MouseOut() {
+	if c.focused {
+		c.moveCursor(0)
+		return
+	}
+	c.hideCrosshair()
+}
+
+// hideCrosshair hides the crosshair and its readout
+func (c *chartWidget) hideCrosshair() {
+	c.vline.Hide()
+	c.hline.Hide()
+	c.readout.Hide()
+}
+
+// chartRenderer lays out the chart image with the crosshair on top
+type chartRenderer struct {
+	chart   *chartWidget
+	objects []fyne.CanvasObject
+}
+
+func (r *chartRenderer) Layout(size fyne.Size) {
+	r.chart.image.Resize(size)
+	r.chart.fallback.Resize(size)
+	if size != r.chart.rendered {
+		r.chart.render(size)
+	}
+}
+
+func (r *chartRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(400, 250)
+}
+
+func (r *chartRenderer) Refresh() {
+	r.chart.render(r.chart.Size())
+}
+
+func (r *chartRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *chartRenderer) Destroy() {}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2/lang"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg"
+)
+
+// modeCompare charts the symbol against others, all rebased to 100
+const modeCompare = "Compare"
+
+// comparedSeries is one symbol of a comparison chart, rebased to 100
+type comparedSeries struct {
+	Symbol string
+	Values []float64
+}
+
+// compareSymbols splits a comma or space separated list of symbols, dropping
+// duplicates and the symbol being compared against
+func compareSymbols(text, symbol string) []string {
+	var out []string
+	seen := map[string]bool{symbol: true}
+	for _, s := range strings.FieldsFunc(strings.ToUpper(text), func(r rune) bool { return r == ',' || r == ' ' }) {
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// rebase lines other up with the dates of base and scales it to 100 at the first
+// date, carrying the last close over days other didn't trade. It reports false
+// if other has no close on or before the first date
+func rebase(base, other []StockData) ([]float64, bool) {
+	closes := make(map[string]float64, len(other))
+	for _, d := range other {
+		closes[d.Date] = d.Close
+	}
+
+	out := make([]float64, len(base))
+	var first, last float64
+	for i, d := range base {
+		if c, ok := closes[d.Date]; ok && c > 0 {
+			last = c
+		}
+		if i == 0 {
+			if last == 0 {
+				return nil, false
+			}
+			first = last
+		}
+		out[i] = last / first * 100
+	}
+	return out, true
+}
+
+// plotCompare charts the rebased series on one chart, dated like data
+func plotCompare(series []comparedSeries, data []StockData) (*plot.Plot, error) {
+	names := make([]string, len(series))
+	for i, s := range series {
+		names[i] = s.Symbol
+	}
+
+	p := newPlot()
+	p.Title.Text = fmt.Sprintf(lang.L("%s (rebased to 100)"), strings.Join(names, " vs "))
+	p.X.Label.Text = lang.L("Date")
+	p.Y.Label.Text = lang.L("Value of 100 invested")
+	dates := make([]string, len(data))
+	for i, d := range data {
+		dates[i] = d.Date
+	}
+	p.X.Tick.Marker = dateTicks{dates: dates, barDays: 1}
+
+	for i, s := range series {
+		points := make(plotter.XYs, len(s.Values))
+		for j, v := range s.Values {
+			points[j].X = float64(j)
+			points[j].Y = v
+		}
+		line, err := plotter.NewLine(points)
+		if err != nil {
+			return nil, err
+		}
+		line.Color = plotutil.Color(i)
+		line.Width = vg.Points(1.5)
+		p.Add(line)
+		p.Legend.Add(s.Symbol, line)
+	}
+	p.Legend.Top = true
+	p.Legend.Left = true
+
+	return p, nil
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+)
+
+// csvDateLayouts are the date formats read from a dropped CSV, tried in order
+var csvDateLayouts = []string{time.RFC3339, "2006-01-02", "2006/01/02", "01/02/2006", "02.01.2006", "20060102"}
+
+// csvColumns lists the header names recognised for each field, lowercased.
+// Close falls back to the adjusted close or a plain price column
+var csvColumns = map[string][]string{
+	"date":   {"date", "timestamp", "time", "day"},
+	"open":   {"open"},
+	"high":   {"high"},
+	"low":    {"low"},
+	"close":  {"close", "adj close", "adj_close", "adjclose", "adjusted close", "price", "value"},
+	"volume": {"volume", "vol"},
+}
+
+// parseCSV reads a price series with a header row, such as one exported from
+// Yahoo Finance or a spreadsheet. It needs a date and a close column, the
+// other OHLC columns default to the close. Rows without a usable close are
+// skipped and the bars are returned oldest first
+func parseCSV(r io.Reader) ([]StockData, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+
+	cols := map[string]int{}
+	for field, names := range csvColumns {
+		cols[field] = -1
+		for _, name := range names {
+			if i := slices.IndexFunc(header, func(h string) bool {
+				return strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")), name)
+			}); i >= 0 {
+				cols[field] = i
+				break
+			}
+		}
+	}
+	if cols["date"] < 0 || cols["close"] < 0 {
+		return nil, errors.New("the file needs a Date and a Close column")
+	}
+
+	var data []StockData
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		value := func(field string) (float64, bool) {
+			i := cols[field]
+			if i < 0 || i >= len(record) {
+				return 0, false
+			}
+			v, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(record[i]), ",", ""), 64)
+			return v, err == nil
+		}
+		price, ok := value("close")
+		if !ok || cols["date"] >= len(record) {
+			continue // blank or "null" rows, as in Yahoo's exports around holidays
+		}
+		date, err := parseCSVDate(record[cols["date"]])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		bar := StockData{Date: date.Format(time.RFC3339), Open: price, High: price, Low: price, Close: price}
+		if v, ok := value("open"); ok {
+			bar.Open = v
+		}
+		if v, ok := value("high"); ok {
+			bar.High = v
+		}
+		if v, ok := value("low"); ok {
+			bar.Low = v
+		}
+		if v, ok := value("volume"); ok {
+			bar.Volume = v
+		}
+		data = append(data, bar)
+	}
+	if len(data) == 0 {
+		return nil, errors.New("no prices found")
+	}
+
+	// Some exports list the newest day first
+	slices.SortStableFunc(data, func(a, b StockData) int { return strings.Compare(a.Date, b.Date) })
+	return data, nil
+}
+
+// parseCSVDate reads a date in any of csvDateLayouts
+func parseCSVDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range csvDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("can't read date %q", s)
+}
+
+// openDroppedFiles charts each CSV file among uris in a tab of its own,
+// named after the file
+func openDroppedFiles(uris []fyne.URI, tabs *workspace, status *statusBar) {
+	opened := 0
+	for _, uri := range uris {
+		if !strings.EqualFold(uri.Extension(), ".csv") {
+			continue
+		}
+		opened++
+		name := uri.Name()
+		data, err := readCSV(uri)
+		if err != nil {
+			log.Println("Error reading CSV:", err)
+			status.Fail(err, "Could not read %s", name)
+			continue
+		}
+		log.Printf("Read %d bars from %s\n", len(data), uri)
+		tabs.OpenFile(name, data)
+	}
+	if opened == 0 && len(uris) > 0 {
+		status.Set("Only CSV files can be dropped on the window")
+	}
+}
+
+// readCSV parses the price series in the file at uri
+func readCSV(uri fyne.URI) ([]StockData, error) {
+	r, err := storage.Reader(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return parseCSV(r)
+}
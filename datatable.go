@@ -0,0 +1,137 @@
+package main
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+)
+
+// dataColumns are the columns of the data table
+var dataColumns = []string{"Date", "Open", "High", "Low", "Close", "Volume", "Forecast"}
+
+// dataRow is a fetched day, or a forecast bar with only Forecast set. Missing
+// values are NaN
+type dataRow struct {
+	Date   string
+	Values [6]float64 // open, high, low, close, volume and forecast
+}
+
+// dataTable lists the fetched rows and the forecast, sorted by the column
+// whose header was last clicked
+type dataTable struct {
+	rows    []dataRow
+	sortCol int
+	desc    bool
+	table   *widget.Table
+}
+
+// newDataTable creates an empty data table
+func newDataTable() *dataTable {
+	t := &dataTable{desc: true}
+	t.table = widget.NewTableWithHeaders(
+		func() (int, int) { return len(t.rows), len(dataColumns) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, o fyne.CanvasObject) {
+			label := o.(*widget.Label)
+			if id.Row >= len(t.rows) {
+				label.SetText("")
+				return
+			}
+			label.Alignment = fyne.TextAlignTrailing
+			row := t.rows[id.Row]
+			switch {
+			case id.Col == 0:
+				label.Alignment = fyne.TextAlignLeading
+				label.SetText(formatDay(row.Date))
+			case math.IsNaN(row.Values[id.Col-1]):
+				label.SetText("")
+			case dataColumns[id.Col] == "Volume":
+				label.SetText(formatNumber(row.Values[id.Col-1], 0))
+			default:
+				label.SetText(formatNumber(row.Values[id.Col-1], 2))
+			}
+		})
+	t.table.ShowHeaderColumn = false
+	t.table.CreateHeader = func() fyne.CanvasObject {
+		return widget.NewButton("", nil)
+	}
+	t.table.UpdateHeader = func(id widget.TableCellID, o fyne.CanvasObject) {
+		button := o.(*widget.Button)
+		if id.Col < 0 {
+			return
+		}
+		text := lang.X("column."+strings.ToLower(dataColumns[id.Col]), dataColumns[id.Col])
+		if id.Col == t.sortCol {
+			text += map[bool]string{false: " ▲", true: " ▼"}[t.desc]
+		}
+		button.SetText(text)
+		button.OnTapped = func() { t.sortBy(id.Col) }
+	}
+	for col := range dataColumns {
+		t.table.SetColumnWidth(col, 110)
+	}
+	return t
+}
+
+// SetData shows the fetched data and the predictions for the bars of
+// barDays days after dates
+func (t *dataTable) SetData(data []StockData, dates []string, barDays int, predictions []float64) {
+	rows := make([]dataRow, 0, len(data)+len(predictions))
+	for _, d := range data {
+		rows = append(rows, dataRow{Date: shortDate(d.Date), Values: [6]float64{d.Open, d.High, d.Low, d.Close, d.Volume, math.NaN()}})
+	}
+	for i, p := range predictions {
+		date, ok := barTime(dates, barDays, len(dates)+i)
+		if !ok {
+			break
+		}
+		nan := math.NaN()
+		rows = append(rows, dataRow{Date: date.Format("2006-01-02"), Values: [6]float64{nan, nan, nan, nan, nan, p}})
+	}
+	t.rows = rows
+	t.sort()
+}
+
+// sortBy orders the rows by col, flipping the direction when it is already
+// the sort column
+func (t *dataTable) sortBy(col int) {
+	if col == t.sortCol {
+		t.desc = !t.desc
+	} else {
+		t.sortCol, t.desc = col, col == 0
+	}
+	t.sort()
+}
+
+// sort orders the rows by the sort column, with empty cells last
+func (t *dataTable) sort() {
+	slices.SortStableFunc(t.rows, func(a, b dataRow) int {
+		if t.sortCol == 0 {
+			return t.direction(cmp.Compare(a.Date, b.Date))
+		}
+		x, y := a.Values[t.sortCol-1], b.Values[t.sortCol-1]
+		switch {
+		case math.IsNaN(x) && math.IsNaN(y):
+			return 0
+		case math.IsNaN(x):
+			return 1
+		case math.IsNaN(y):
+			return -1
+		}
+		return t.direction(cmp.Compare(x, y))
+	})
+	t.table.Refresh()
+}
+
+// direction turns an ascending comparison into the table's sort order
+func (t *dataTable) direction(c int) int {
+	if t.desc {
+		return -c
+	}
+	return c
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"gomarket/forecast"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// decompositionPeriods are the seasonal periods offered, in trading days
+var decompositionPeriods = map[string]int{
+	"Weekly (5)":     5,
+	"Monthly (21)":   21,
+	"Quarterly (63)": 63,
+}
+
+// plotDecomposition builds stacked charts of the series and its STL trend,
+// seasonal and residual components and returns a function drawing them
+func plotDecomposition(prices []float64, symbol string, period int) (func(draw.Canvas), error) {
+	d, err := forecast.STL(prices, period)
+	if err != nil {
+		return nil, err
+	}
+
+	panels := []struct {
+		name   string
+		values []float64
+	}{
+		{"Observed", prices},
+		{"Trend", d.Trend},
+		{"Seasonal", d.Seasonal},
+		{"Residual", d.Residual},
+	}
+
+	plots := make([][]*plot.Plot, len(panels))
+	for i, panel := range panels {
+		p := newPlot()
+		p.Y.Label.Text = lang.L(panel.name)
+		if i == 0 {
+			p.Title.Text = fmt.Sprintf(lang.L("STL Decomposition for %s (period %d)"), symbol, period)
+		}
+		if i == len(panels)-1 {
+			p.X.Label.Text = lang.L("Days")
+		}
+
+		points := make(plotter.XYs, len(panel.values))
+		for j, v := range panel.values {
+			points[j].X = float64(j)
+			points[j].Y = v
+		}
+		line, err := plotter.NewLine(points)
+		if err != nil {
+			return nil, err
+		}
+		line.Color = color.RGBA{R: 255, A: 255}
+		p.Add(line)
+		plots[i] = []*plot.Plot{p}
+	}
+
+	return func(dc draw.Canvas) {
+		tiles := draw.Tiles{Rows: len(plots), Cols: 1, PadY: vg.Points(4)}
+		canvases := plot.Align(plots, tiles, dc)
+		for i := range plots {
+			plots[i][0].Draw(canvases[i][0])
+		}
+	}, nil
+}
+
+// showDecomposition opens a window with the STL decomposition of the view's symbol
+func showDecomposition(a fyne.App, v *symbolView) {
+	if len(v.prices) == 0 {
+		v.status.Set("Fetch a symbol before opening the decomposition")
+		return
+	}
+	prices, symbol := v.prices, v.symbol
+
+	chart := container.NewStack()
+	render := func(period int) {
+		stl, err := plotDecomposition(prices, symbol, period)
+		if err != nil {
+			v.status.Fail(err, "Decomposition failed for %s", symbol)
+			return
+		}
+		img := newPlotImage(stl)
+		img.onError = func(err error) {
+			v.status.Fail(err, "Decomposition failed for %s", symbol)
+		}
+		chart.Objects = []fyne.CanvasObject{img}
+		chart.Refresh()
+	}
+
+	periodSelect := newLocalSelect([]string{"Weekly (5)", "Monthly (21)", "Quarterly (63)"}, func(label string) {
+		render(decompositionPeriods[label])
+	})
+
+	w := a.NewWindow(lang.L("Decomposition") + " - " + symbol)
+	w.SetContent(container.NewBorder(periodSelect, nil, nil, nil, chart))
+	w.Resize(fyne.NewSize(800, 800))
+	periodSelect.SetValue("Weekly (5)")
+	w.Show()
+}
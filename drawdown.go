@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"slices"
+
+	"fyne.io/fyne/v2/lang"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// drawdownColor shades the drawdown panel
+var drawdownColor = color.NRGBA{R: 220, A: 90}
+
+// drawdowns returns the percent fall of each of prices from the highest
+// price before it, 0 at new highs
+func drawdowns(prices []float64) []float64 {
+	out := make([]float64, len(prices))
+	peak := math.Inf(-1)
+	for i, p := range prices {
+		peak = math.Max(peak, p)
+		if peak > 0 {
+			out[i] = (p/peak - 1) * 100
+		}
+	}
+	return out
+}
+
+// drawdownPlot builds the drawdown panel for the price plot p, with the
+// running peak starting over at the first visible bar
+func drawdownPlot(prices []float64, p *plot.Plot) (*plot.Plot, error) {
+	dp := newPlot()
+	dp.X.Tick.Marker = p.X.Tick.Marker
+	dp.X.Min, dp.X.Max = p.X.Min, p.X.Max
+	dp.Y.Label.Text = lang.L("Drawdown %")
+	dp.Y.Min, dp.Y.Max = -1, 0
+
+	first := min(max(int(math.Ceil(p.X.Min)), 0), len(prices))
+	last := min(int(math.Floor(p.X.Max)), len(prices)-1)
+	if last <= first {
+		return dp, nil
+	}
+
+	dd := drawdowns(prices[first : last+1])
+	poly := plotter.XYs{{X: float64(first), Y: 0}}
+	for i, v := range dd {
+		poly = append(poly, plotter.XY{X: float64(first + i), Y: v})
+		dp.Y.Min = math.Min(dp.Y.Min, v)
+	}
+	poly = append(poly, plotter.XY{X: float64(last), Y: 0})
+
+	shade, err := plotter.NewPolygon(poly)
+	if err != nil {
+		return nil, err
+	}
+	shade.Color = drawdownColor
+	shade.LineStyle.Color = candleDown
+	shade.LineStyle.Width = vg.Points(0.75)
+	dp.Add(shade)
+	dp.Legend.Add(fmt.Sprintf(lang.L("Max %s"), formatPercent(slices.Min(dd))), shade)
+	return dp, nil
+}
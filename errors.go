@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"fyne.io/fyne/v2/lang"
+)
+
+// Fetch failures the user can do something about
+var (
+	errBadSymbol   = errors.New("symbol not found")
+	errBadAPIKey   = errors.New("API key rejected")
+	errRateLimited = errors.New("rate limited")
+	errOffline     = errors.New("no network connection")
+)
+
+// responseError turns a Tiingo reply that isn't price data into an error,
+// using the status code and the detail message Tiingo sends back
+func responseError(status int, body []byte) error {
+	var reply struct {
+		Detail string `json:"detail"`
+	}
+	json.Unmarshal(body, &reply)
+	msg := reply.Detail
+	if msg == "" {
+		msg = http.StatusText(status)
+	}
+
+	lower := strings.ToLower(msg)
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden || strings.Contains(lower, "token"):
+		return fmt.Errorf("%w: %s", errBadAPIKey, msg)
+	case status == http.StatusNotFound || strings.Contains(lower, "not found"):
+		return fmt.Errorf("%w: %s", errBadSymbol, msg)
+	case status == http.StatusTooManyRequests || strings.Contains(lower, "allocation") || strings.Contains(lower, "limit"):
+		return fmt.Errorf("%w: %s", errRateLimited, msg)
+	}
+	return fmt.Errorf("Tiingo returned %q", msg)
+}
+
+// errorAdvice tells the user how to fix err, or returns an empty string
+func errorAdvice(err error) string {
+	switch {
+	case errors.Is(err, errBadSymbol):
+		return lang.L("Check the ticker is spelled correctly. Tiingo uses dashes for share classes, e.g. BRK-B.")
+	case errors.Is(err, errBadAPIKey):
+		return lang.L("Set api_key in the settings file to your Tiingo API token, found under Account > API on tiingo.com.")
+	case errors.Is(err, errRateLimited):
+		return lang.L("Tiingo's hourly request allowance is used up. Wait a while before fetching again.")
+	case errors.Is(err, errOffline):
+		return lang.L("Check your internet connection and try again.")
+	}
+	return ""
+}
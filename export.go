@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"gomarket/stats"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+	"gonum.org/v1/plot/vg/vgpdf"
+	"gonum.org/v1/plot/vg/vgsvg"
+)
+
+// chartFormats are the file types a chart can be exported as
+var chartFormats = []string{".svg", ".pdf", ".png"}
+
+// exportCanvas is a vector or image canvas that can be written to a file
+type exportCanvas interface {
+	vg.CanvasSizer
+	io.WriterTo
+}
+
+// writeChart draws the price plot and the panels below it at width by height
+// in the format of ext and writes it to w
+func writeChart(w io.Writer, price *plot.Plot, panels []*plot.Plot, ext string, width, height vg.Length) error {
+	var c exportCanvas
+	switch ext {
+	case ".svg":
+		c = vgsvg.New(width, height)
+	case ".pdf":
+		c = vgpdf.New(width, height)
+	case ".png":
+		c = vgimg.PngCanvas{Canvas: newChartCanvas(width, height, vgimg.DefaultDPI)}
+	default:
+		return fmt.Errorf("unsupported chart format %q", ext)
+	}
+
+	dc := draw.New(c)
+	bg, _ := chartPalette()
+	dc.SetColor(bg)
+	dc.Fill(dc.Rectangle.Path())
+	drawChart(price, panels, dc)
+	_, err := c.WriteTo(w)
+	return err
+}
+
+// exportChart asks for a size and file and saves the view's price chart to
+// it, as currently zoomed, in the format given by the file extension
+func exportChart(win fyne.Window, v *symbolView, status *statusBar) {
+	c := v.plot
+	if len(c.data.Prices) == 0 || v.mode.Value() != modePrice {
+		status.Set("Load a price chart to export it")
+		return
+	}
+
+	width, height := widget.NewEntry(), widget.NewEntry()
+	width.SetText("8")
+	height.SetText("4")
+	items := []*widget.FormItem{
+		widget.NewFormItem(lang.L("Width (inches)"), width),
+		widget.NewFormItem(lang.L("Height (inches)"), height),
+	}
+	dialog.ShowForm(lang.L("Export Chart"), lang.L("Choose File..."), lang.L("Cancel"), items, func(ok bool) {
+		if !ok {
+			return
+		}
+		w, errW := strconv.ParseFloat(width.Text, 64)
+		h, errH := strconv.ParseFloat(height.Text, 64)
+		if errW != nil || errH != nil || w <= 0 || h <= 0 || w > 100 || h > 100 {
+			status.Set("Chart width and height must be between 0 and 100 inches")
+			return
+		}
+
+		d := dialog.NewFileSave(func(wc fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			if wc == nil {
+				return // cancelled
+			}
+			defer wc.Close()
+
+			ext := strings.ToLower(filepath.Ext(wc.URI().Path()))
+			price, panels, err := chartPlots(c.data, c.start, c.end)
+			if err == nil {
+				err = writeChart(wc, price, panels, ext, vg.Length(w)*vg.Inch, vg.Length(h)*vg.Inch)
+			}
+			if err != nil {
+				dialog.ShowError(err, win)
+				status.Set("Chart export failed: %v", err)
+				return
+			}
+			status.Set("Exported chart to %s", wc.URI().Path())
+		}, win)
+		d.SetFileName(c.data.Symbol + ".svg")
+		d.SetFilter(storage.NewExtensionFileFilter(chartFormats))
+		d.Show()
+	}, win)
+}
+
+// statsHeader names the columns written by writeStats
+var statsHeader = []string{"symbol", "last_close", "change", "change_percent", "period_return", "high_52w", "low_52w", "average_volume", "volatility"}
+
+// writeStats writes the summary stats of symbol to w as CSV, leaving a
+// figure that couldn't be computed empty
+func writeStats(w io.Writer, symbol string, s stats.Summary) error {
+	row := []string{symbol}
+	for _, v := range []float64{s.LastClose, s.Change, s.ChangePercent, s.PeriodReturn, s.High52, s.Low52, s.AverageVolume, s.Volatility} {
+		text := ""
+		if !math.IsNaN(v) {
+			text = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		row = append(row, text)
+	}
+	cw := csv.NewWriter(w)
+	cw.Write(statsHeader)
+	cw.Write(row)
+	cw.Flush()
+	return cw.Error()
+}
+
+// exportStats asks for a file and saves the view's summary stats to it as CSV
+func exportStats(win fyne.Window, v *symbolView, status *statusBar) {
+	p := v.stats
+	if p.symbol == "" {
+		status.Set("Load a symbol to export its stats")
+		return
+	}
+	symbol, summary := p.symbol, p.summary
+	d := dialog.NewFileSave(func(wc fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		if wc == nil {
+			return // cancelled
+		}
+		defer wc.Close()
+
+		if err := writeStats(wc, symbol, summary); err != nil {
+			dialog.ShowError(err, win)
+			status.Set("Stats export failed: %v", err)
+			return
+		}
+		status.Set("Exported stats to %s", wc.URI().Path())
+	}, win)
+	d.SetFileName(symbol + "-stats.csv")
+	d.SetFilter(storage.NewExtensionFileFilter([]string{".csv"}))
+	d.Show()
+}
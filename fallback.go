@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// fallbackRows is how many of the latest values the fallback view lists
+const fallbackRows = 10
+
+// sparkBlocks draw a sparkline from low to high
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline draws values as block characters, averaging them into at most width columns
+func sparkline(values []float64, width int) string {
+	if len(values) == 0 || width < 1 {
+		return ""
+	}
+	cols := make([]float64, 0, width)
+	for i := 0; i < min(width, len(values)); i++ {
+		from, to := i*len(values)/min(width, len(values)), (i+1)*len(values)/min(width, len(values))
+		sum := 0.0
+		for _, v := range values[from:to] {
+			sum += v
+		}
+		cols = append(cols, sum/float64(to-from))
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range cols {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	var b strings.Builder
+	for _, v := range cols {
+		i := 0
+		if hi > lo {
+			i = int((v - lo) / (hi - lo) * float64(len(sparkBlocks)-1))
+		}
+		b.WriteRune(sparkBlocks[i])
+	}
+	return b.String()
+}
+
+// fallbackText describes series in text when it can't be charted: a sparkline
+// and the latest values, labelled by label(i)
+func fallbackText(title string, values []float64, label func(i int) string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n%s\n\n", title, sparkline(values, 60))
+	for i := len(values) - 1; i >= 0 && i >= len(values)-fallbackRows; i-- {
+		fmt.Fprintf(&b, "%-12s %12s\n", label(i), formatNumber(values[i], 2))
+	}
+	return b.String()
+}
@@ -0,0 +1,329 @@
+package forecast
+
+import (
+	"fmt"
+	"math"
+)
+
+// ARIMA is an ARIMA(p,d,q)(P,D,Q)[s] model fitted with the Hannan-Rissanen
+// method. The seasonal terms are added as extra lags rather than multiplied
+// out, and a period S of 0 leaves the model non-seasonal.
+type ARIMA struct {
+	P, D, Q int
+
+	SP, SD, SQ int // seasonal AR, differencing and MA orders
+	S          int // seasonal period in observations
+
+	series []float64 // original series the model was fitted to
+	mean   float64   // mean of the differenced series
+	phi    []float64 // autoregressive coefficients, one per arLags entry
+	theta  []float64 // moving average coefficients, one per maLags entry
+	resid  []float64 // in-sample one-step residuals of the differenced series
+	sigma2 float64   // residual variance
+	nobs   int       // number of residuals sigma2 was estimated from
+	fitted bool
+}
+
+// NewARIMA creates an unfitted ARIMA(p,d,q) model
+func NewARIMA(p, d, q int) *ARIMA {
+	return &ARIMA{P: p, D: d, Q: q}
+}
+
+// NewSeasonalARIMA creates an unfitted ARIMA(p,d,q)(sp,sd,sq)[s] model
+func NewSeasonalARIMA(p, d, q, sp, sd, sq, s int) *ARIMA {
+	return &ARIMA{P: p, D: d, Q: q, SP: sp, SD: sd, SQ: sq, S: s}
+}
+
+// String returns the model order, e.g. "ARIMA(5,1,0)" or "ARIMA(1,1,1)(0,1,1)[5]"
+func (m *ARIMA) String() string {
+	if !m.seasonal() {
+		return fmt.Sprintf("ARIMA(%d,%d,%d)", m.P, m.D, m.Q)
+	}
+	return fmt.Sprintf("ARIMA(%d,%d,%d)(%d,%d,%d)[%d]", m.P, m.D, m.Q, m.SP, m.SD, m.SQ, m.S)
+}
+
+// Fit estimates the model coefficients from series
+func (m *ARIMA) Fit(series []float64) error {
+	if m.P < 0 || m.D < 0 || m.Q < 0 || m.SP < 0 || m.SD < 0 || m.SQ < 0 || m.S < 0 {
+		return fmt.Errorf("forecast: invalid order %s", m)
+	}
+	if m.SP+m.SD+m.SQ > 0 && m.S < 2 {
+		return fmt.Errorf("forecast: seasonal order of %s needs a period of at least 2", m)
+	}
+
+	// Each round of seasonal differencing uses up S points, and a round the
+	// series is too short for would leave Forecast integrating before its start
+	ar, ma := m.arLags(), m.maLags()
+	maxAR, maxMA := maxLag(ar), maxLag(ma)
+	if len(series) <= m.S*m.SD+m.D+max(maxAR, maxMA) {
+		return ErrTooShort
+	}
+
+	z := m.transform(series)
+	m.mean = mean(z)
+	for i := range z {
+		z[i] -= m.mean
+	}
+
+	// Moving average terms need estimates of the past innovations, which
+	// are taken from the residuals of a long autoregression
+	innov := make([]float64, len(z))
+	start := max(maxAR, maxMA)
+	if len(ma) > 0 {
+		k := max(maxAR+maxMA, 10)
+		if len(z) < 2*k+len(ar)+len(ma)+1 {
+			return ErrTooShort
+		}
+		long, err := fitAR(z, k)
+		if err != nil {
+			return err
+		}
+		for t := k; t < len(z); t++ {
+			innov[t] = z[t] - dot(long, lags(z, t, k))
+		}
+		start += k
+	}
+
+	n := len(z) - start
+	if n <= len(ar)+len(ma) {
+		return ErrTooShort
+	}
+	x := make([][]float64, n)
+	y := make([]float64, n)
+	for t := start; t < len(z); t++ {
+		x[t-start] = append(at(z, t, ar), at(innov, t, ma)...)
+		y[t-start] = z[t]
+	}
+	coef, err := leastSquares(x, y)
+	if err != nil {
+		return err
+	}
+	m.phi = coef[:len(ar)]
+	m.theta = coef[len(ar):]
+
+	// Recompute the residuals with the fitted model for forecasting and variance
+	m.resid = make([]float64, len(z))
+	ss := 0.0
+	for t := max(maxAR, maxMA); t < len(z); t++ {
+		m.resid[t] = z[t] - dot(m.phi, at(z, t, ar)) - dot(m.theta, at(m.resid, t, ma))
+		ss += m.resid[t] * m.resid[t]
+	}
+	m.nobs = len(z) - max(maxAR, maxMA)
+	m.sigma2 = ss / float64(m.nobs)
+
+	m.series = append([]float64(nil), series...)
+	m.fitted = true
+	return nil
+}
+
+// Forecast predicts the next horizon values of the fitted series
+func (m *ARIMA) Forecast(horizon int) ([]float64, error) {
+	if !m.fitted {
+		return nil, ErrNotFitted
+	}
+
+	z := m.transform(m.series)
+	for i := range z {
+		z[i] -= m.mean
+	}
+	e := append([]float64(nil), m.resid...)
+	ar, ma := m.arLags(), m.maLags()
+
+	out := make([]float64, horizon)
+	for h := 0; h < horizon; h++ {
+		t := len(z)
+		next := dot(m.phi, at(z, t, ar)) + dot(m.theta, at(e, t, ma))
+		z = append(z, next)
+		e = append(e, 0) // future innovations are expected to be zero
+		out[h] = next + m.mean
+	}
+
+	out = integrate(seasonalDifference(m.series, m.S, m.SD), out, m.D)
+	return seasonalIntegrate(m.series, out, m.S, m.SD), nil
+}
+
+// Interval returns normal prediction bounds from the model's psi weights
+func (m *ARIMA) Interval(horizon int, level float64) (lower, upper []float64, err error) {
+	points, err := m.Forecast(horizon)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Fold the differencing into the AR polynomial: phi(B)(1-B)^d(1-B^s)^D
+	ar, ma := m.arLags(), m.maLags()
+	poly := make([]float64, maxLag(ar)+1)
+	poly[0] = 1
+	for i, l := range ar {
+		poly[l] = -m.phi[i]
+	}
+	for i := 0; i < m.D; i++ {
+		poly = differencePoly(poly, 1)
+	}
+	for i := 0; i < m.SD; i++ {
+		poly = differencePoly(poly, m.S)
+	}
+	thetas := make([]float64, maxLag(ma)+1)
+	for i, l := range ma {
+		thetas[l] = m.theta[i]
+	}
+
+	psi := make([]float64, horizon)
+	stderr := make([]float64, horizon)
+	total := 0.0
+	for j := 0; j < horizon; j++ {
+		if j == 0 {
+			psi[j] = 1
+		} else {
+			if j < len(thetas) {
+				psi[j] = thetas[j]
+			}
+			for i := 1; i < len(poly) && i <= j; i++ {
+				psi[j] -= poly[i] * psi[j-i]
+			}
+		}
+		total += psi[j] * psi[j]
+		stderr[j] = math.Sqrt(m.sigma2 * total)
+	}
+
+	lower, upper = normalInterval(points, stderr, level)
+	return lower, upper, nil
+}
+
+func (m *ARIMA) seasonal() bool {
+	return m.S > 0 && m.SP+m.SD+m.SQ > 0
+}
+
+// transform applies the seasonal and then the regular differencing to series
+func (m *ARIMA) transform(series []float64) []float64 {
+	return difference(seasonalDifference(series, m.S, m.SD), m.D)
+}
+
+// arLags returns the autoregressive lags: 1..p followed by s, 2s, ..., P*s
+func (m *ARIMA) arLags() []int {
+	return modelLags(m.P, m.SP, m.S)
+}
+
+// maLags returns the moving average lags: 1..q followed by s, 2s, ..., Q*s
+func (m *ARIMA) maLags() []int {
+	return modelLags(m.Q, m.SQ, m.S)
+}
+
+// modelLags returns lags 1..n and the first sn multiples of s, skipping
+// seasonal lags already covered by the regular ones
+func modelLags(n, sn, s int) []int {
+	out := make([]int, 0, n+sn)
+	for l := 1; l <= n; l++ {
+		out = append(out, l)
+	}
+	for i := 1; i <= sn && s > 0; i++ {
+		if l := i * s; l > n {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+func maxLag(ls []int) int {
+	if len(ls) == 0 {
+		return 0
+	}
+	return ls[len(ls)-1]
+}
+
+// differencePoly multiplies a lag polynomial by (1-B^s)
+func differencePoly(poly []float64, s int) []float64 {
+	out := make([]float64, len(poly)+s)
+	for j, c := range poly {
+		out[j] += c
+		out[j+s] -= c
+	}
+	return out
+}
+
+// fitAR fits an AR(k) model without a constant by least squares
+func fitAR(z []float64, k int) ([]float64, error) {
+	x := make([][]float64, 0, len(z)-k)
+	y := make([]float64, 0, len(z)-k)
+	for t := k; t < len(z); t++ {
+		x = append(x, lags(z, t, k))
+		y = append(y, z[t])
+	}
+	return leastSquares(x, y)
+}
+
+// lags returns v[t-1], v[t-2], ..., v[t-n]
+func lags(v []float64, t, n int) []float64 {
+	out := make([]float64, n)
+	for i := 0; i < n; i++ {
+		out[i] = v[t-1-i]
+	}
+	return out
+}
+
+// at returns v[t-l] for each lag l
+func at(v []float64, t int, ls []int) []float64 {
+	out := make([]float64, len(ls))
+	for i, l := range ls {
+		out[i] = v[t-l]
+	}
+	return out
+}
+
+func dot(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+// difference applies first differencing d times
+func difference(series []float64, d int) []float64 {
+	out := append([]float64(nil), series...)
+	for i := 0; i < d && len(out) > 0; i++ {
+		for j := 0; j < len(out)-1; j++ {
+			out[j] = out[j+1] - out[j]
+		}
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+// seasonalDifference applies lag s differencing d times
+func seasonalDifference(series []float64, s, d int) []float64 {
+	out := append([]float64(nil), series...)
+	for i := 0; i < d && s > 0 && len(out) > s; i++ {
+		for j := 0; j < len(out)-s; j++ {
+			out[j] = out[j+s] - out[j]
+		}
+		out = out[:len(out)-s]
+	}
+	return out
+}
+
+// integrate undoes d rounds of differencing on values that continue series
+func integrate(series, values []float64, d int) []float64 {
+	out := append([]float64(nil), values...)
+	for level := d - 1; level >= 0; level-- {
+		base := difference(series, level)
+		last := base[len(base)-1]
+		for i := range out {
+			last += out[i]
+			out[i] = last
+		}
+	}
+	return out
+}
+
+// seasonalIntegrate undoes d rounds of lag s differencing on values that continue series
+func seasonalIntegrate(series, values []float64, s, d int) []float64 {
+	out := append([]float64(nil), values...)
+	for level := d - 1; level >= 0; level-- {
+		full := seasonalDifference(series, s, level)
+		for i := range out {
+			out[i] += full[len(full)-s]
+			full = append(full, out[i])
+		}
+	}
+	return out
+}
@@ -0,0 +1,117 @@
+package forecast
+
+import (
+	"fmt"
+	"math"
+)
+
+// Information criteria AutoARIMA can rank candidate orders by
+const (
+	AIC = "AIC"
+	BIC = "BIC"
+)
+
+// AutoARIMA picks the ARIMA order for a series automatically. The differencing
+// order is the one that gives the lowest variance, then every p and q up to
+// the limits is fitted and the best by the information criterion is kept.
+type AutoARIMA struct {
+	MaxP, MaxD, MaxQ int
+	Criterion        string // AIC or BIC
+
+	best  *ARIMA
+	score float64
+}
+
+// NewAutoARIMA creates an order search up to ARIMA(5,2,5) ranked by AIC
+func NewAutoARIMA() *AutoARIMA {
+	return &AutoARIMA{MaxP: 5, MaxD: 2, MaxQ: 5, Criterion: AIC}
+}
+
+// String returns the chosen order once fitted
+func (m *AutoARIMA) String() string {
+	if m.best == nil {
+		return "Auto ARIMA"
+	}
+	return fmt.Sprintf("Auto %s (%s %.1f)", m.best, m.Criterion, m.score)
+}
+
+// Order returns the selected p, d and q
+func (m *AutoARIMA) Order() (p, d, q int) {
+	if m.best == nil {
+		return 0, 0, 0
+	}
+	return m.best.P, m.best.D, m.best.Q
+}
+
+// Fit searches the candidate orders and keeps the best fitting model
+func (m *AutoARIMA) Fit(series []float64) error {
+	d := chooseDifferencing(series, m.MaxD)
+
+	m.best = nil
+	m.score = math.Inf(1)
+	var lastErr error
+	for p := 0; p <= m.MaxP; p++ {
+		for q := 0; q <= m.MaxQ; q++ {
+			candidate := NewARIMA(p, d, q)
+			if err := candidate.Fit(series); err != nil {
+				lastErr = err
+				continue
+			}
+			if score := m.criterion(candidate); score < m.score {
+				m.best, m.score = candidate, score
+			}
+		}
+	}
+	if m.best == nil {
+		if lastErr == nil {
+			lastErr = ErrTooShort
+		}
+		return lastErr
+	}
+	return nil
+}
+
+// criterion scores a fitted candidate, lower is better
+func (m *AutoARIMA) criterion(a *ARIMA) float64 {
+	n := float64(a.nobs)
+	k := float64(a.P + a.Q + 1)
+	if a.sigma2 <= 0 || n == 0 {
+		return math.Inf(1)
+	}
+	penalty := 2 * k
+	if m.Criterion == BIC {
+		penalty = math.Log(n) * k
+	}
+	return n*math.Log(a.sigma2) + penalty
+}
+
+// chooseDifferencing returns the number of differences, up to maxD, after
+// which differencing again no longer lowers the variance
+func chooseDifferencing(series []float64, maxD int) int {
+	best := 0
+	bestVar := stddev(series)
+	for d := 1; d <= maxD; d++ {
+		v := stddev(difference(series, d))
+		if v >= bestVar {
+			break
+		}
+		best, bestVar = d, v
+	}
+	return best
+}
+
+// Forecast predicts with the selected model
+func (m *AutoARIMA) Forecast(horizon int) ([]float64, error) {
+	if m.best == nil {
+		return nil, ErrNotFitted
+	}
+	return m.best.Forecast(horizon)
+}
+
+// Interval returns the prediction bounds of the selected model
+func (m *AutoARIMA) Interval(horizon int, level float64) (lower, upper []float64, err error) {
+	if m.best == nil {
+		return nil, nil, ErrNotFitted
+	}
+	return m.best.Interval(horizon, level)
+}
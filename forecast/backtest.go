@@ -0,0 +1,58 @@
+package forecast
+
+import "math"
+
+// Accuracy summarizes the errors of a walk-forward backtest
+type Accuracy struct {
+	MAPE  float64 // mean absolute percentage error
+	RMSE  float64 // root mean squared error
+	Folds int     // number of windows that produced a forecast
+}
+
+// WalkForward fits a fresh model on each rolling window of the series,
+// forecasts the next horizon bars and scores them against what happened.
+// The window moves forward step bars at a time.
+func WalkForward(series []float64, newModel func() Forecaster, window, horizon, step int) (Accuracy, error) {
+	if window+horizon > len(series) {
+		return Accuracy{}, ErrTooShort
+	}
+	if step < 1 {
+		step = 1
+	}
+
+	var acc Accuracy
+	var absPct, sq float64
+	var count int
+	var lastErr error
+	for start := 0; start+window+horizon <= len(series); start += step {
+		train := series[start : start+window]
+		actual := series[start+window : start+window+horizon]
+
+		model := newModel()
+		if err := model.Fit(train); err != nil {
+			lastErr = err
+			continue
+		}
+		predicted, err := model.Forecast(horizon)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		for i := range actual {
+			e := predicted[i] - actual[i]
+			sq += e * e
+			if actual[i] != 0 {
+				absPct += math.Abs(e / actual[i])
+			}
+			count++
+		}
+		acc.Folds++
+	}
+	if acc.Folds == 0 {
+		return acc, lastErr
+	}
+	acc.MAPE = absPct / float64(count) * 100
+	acc.RMSE = math.Sqrt(sq / float64(count))
+	return acc, nil
+}
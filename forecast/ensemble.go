@@ -0,0 +1,154 @@
+package forecast
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Ensemble combines the forecasts of several models, either as a plain mean
+// or weighted by the inverse of each member's error on the most recent bars
+type Ensemble struct {
+	Members  []Forecaster
+	Weighted bool
+	Holdout  int // bars held back to measure each member's recent error
+
+	weights []float64
+	fitted  bool
+}
+
+// NewEnsemble combines members, weighting them by recent error if weighted is set
+func NewEnsemble(weighted bool, members ...Forecaster) *Ensemble {
+	return &Ensemble{Members: members, Weighted: weighted, Holdout: 20}
+}
+
+// String lists the members with their weights
+func (m *Ensemble) String() string {
+	kind := "mean"
+	if m.Weighted {
+		kind = "weighted"
+	}
+	if !m.fitted {
+		return fmt.Sprintf("Ensemble(%s)", kind)
+	}
+	parts := make([]string, 0, len(m.Members))
+	for i, member := range m.Members {
+		if m.weights[i] > 0 {
+			parts = append(parts, fmt.Sprintf("%s %.0f%%", member, m.weights[i]*100))
+		}
+	}
+	return fmt.Sprintf("Ensemble(%s: %s)", kind, strings.Join(parts, ", "))
+}
+
+// Weights returns the share of each member in the combined forecast
+func (m *Ensemble) Weights() []float64 {
+	return m.weights
+}
+
+// Fit fits every member, scoring them on a holdout first when weighting
+func (m *Ensemble) Fit(series []float64) error {
+	m.weights = make([]float64, len(m.Members))
+
+	if m.Weighted && len(series) > 2*m.Holdout {
+		train, test := series[:len(series)-m.Holdout], series[len(series)-m.Holdout:]
+		for i, member := range m.Members {
+			if err := member.Fit(train); err != nil {
+				continue
+			}
+			predicted, err := member.Forecast(len(test))
+			if err != nil {
+				continue
+			}
+			ss := 0.0
+			for j := range test {
+				ss += (predicted[j] - test[j]) * (predicted[j] - test[j])
+			}
+			m.weights[i] = 1 / math.Max(math.Sqrt(ss/float64(len(test))), 1e-9)
+		}
+	} else {
+		for i := range m.weights {
+			m.weights[i] = 1
+		}
+	}
+
+	var lastErr error
+	total := 0.0
+	for i, member := range m.Members {
+		if m.weights[i] == 0 {
+			continue
+		}
+		if err := member.Fit(series); err != nil {
+			lastErr = err
+			m.weights[i] = 0
+			continue
+		}
+		total += m.weights[i]
+	}
+	if total == 0 {
+		if lastErr == nil {
+			lastErr = ErrTooShort
+		}
+		return lastErr
+	}
+	for i := range m.weights {
+		m.weights[i] /= total
+	}
+	m.fitted = true
+	return nil
+}
+
+// MemberForecasts returns each member's own forecast, nil for members left out
+func (m *Ensemble) MemberForecasts(horizon int) ([][]float64, error) {
+	if !m.fitted {
+		return nil, ErrNotFitted
+	}
+	out := make([][]float64, len(m.Members))
+	for i, member := range m.Members {
+		if m.weights[i] == 0 {
+			continue
+		}
+		f, err := member.Forecast(horizon)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = f
+	}
+	return out, nil
+}
+
+// Forecast returns the weighted mean of the member forecasts
+func (m *Ensemble) Forecast(horizon int) ([]float64, error) {
+	members, err := m.MemberForecasts(horizon)
+	if err != nil {
+		return nil, err
+	}
+	return m.combine(members, horizon), nil
+}
+
+// Interval combines the members' bounds with the same weights as the forecast
+func (m *Ensemble) Interval(horizon int, level float64) (lower, upper []float64, err error) {
+	if !m.fitted {
+		return nil, nil, ErrNotFitted
+	}
+	lowers := make([][]float64, len(m.Members))
+	uppers := make([][]float64, len(m.Members))
+	for i, member := range m.Members {
+		if m.weights[i] == 0 {
+			continue
+		}
+		if lowers[i], uppers[i], err = member.Interval(horizon, level); err != nil {
+			return nil, nil, err
+		}
+	}
+	return m.combine(lowers, horizon), m.combine(uppers, horizon), nil
+}
+
+func (m *Ensemble) combine(series [][]float64, horizon int) []float64 {
+	out := make([]float64, horizon)
+	for i, s := range series {
+		for h := range s {
+			out[h] += m.weights[i] * s[h]
+		}
+	}
+	return out
+}
@@ -0,0 +1,45 @@
+// Package forecast implements the time series models used to predict stock prices
+package forecast
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	// ErrTooShort is returned when a series has too few points to fit a model
+	ErrTooShort = errors.New("forecast: series too short for model")
+
+	// ErrNotFitted is returned when forecasting with a model that hasn't been fitted
+	ErrNotFitted = errors.New("forecast: model has not been fitted")
+)
+
+// Forecaster is implemented by every model that can be fitted to a series and
+// then predict its continuation
+type Forecaster interface {
+	fmt.Stringer
+
+	// Fit estimates the model from the series
+	Fit(series []float64) error
+
+	// Forecast predicts the next horizon values after the fitted series
+	Forecast(horizon int) ([]float64, error)
+
+	// Interval returns the bounds the next horizon values are expected to fall
+	// within with the given probability, e.g. 0.95
+	Interval(horizon int, level float64) (lower, upper []float64, err error)
+}
+
+// normalInterval builds symmetric bounds around point forecasts from the
+// standard error of each step, assuming normally distributed errors
+func normalInterval(points, stderr []float64, level float64) (lower, upper []float64) {
+	z := math.Sqrt2 * math.Erfinv(level)
+	lower = make([]float64, len(points))
+	upper = make([]float64, len(points))
+	for i, p := range points {
+		lower[i] = p - z*stderr[i]
+		upper[i] = p + z*stderr[i]
+	}
+	return lower, upper
+}
@@ -0,0 +1,97 @@
+package forecast
+
+import (
+	"fmt"
+	"math"
+)
+
+// GARCH is a GARCH(1,1) model of the daily volatility of log returns. The
+// long-run variance is targeted to the sample variance and alpha and beta
+// are chosen by a grid search on the Gaussian likelihood.
+type GARCH struct {
+	omega, alpha, beta float64
+	longRun            float64 // unconditional variance
+	nextVar            float64 // variance forecast for the bar after the series
+	fitted             bool
+}
+
+// NewGARCH creates an unfitted GARCH(1,1) model
+func NewGARCH() *GARCH {
+	return &GARCH{}
+}
+
+// String returns the model name and its fitted parameters
+func (m *GARCH) String() string {
+	if !m.fitted {
+		return "GARCH(1,1)"
+	}
+	return fmt.Sprintf("GARCH(1,1, α=%.2f β=%.2f)", m.alpha, m.beta)
+}
+
+// Fit estimates the model from a price series
+func (m *GARCH) Fit(prices []float64) error {
+	if len(prices) < 30 {
+		return ErrTooShort
+	}
+	returns := make([]float64, 0, len(prices)-1)
+	for i := 1; i < len(prices); i++ {
+		if prices[i-1] <= 0 || prices[i] <= 0 {
+			return fmt.Errorf("forecast: GARCH needs positive prices")
+		}
+		returns = append(returns, math.Log(prices[i]/prices[i-1]))
+	}
+	mu := mean(returns)
+	for i := range returns {
+		returns[i] -= mu
+	}
+
+	m.longRun = 0
+	for _, r := range returns {
+		m.longRun += r * r
+	}
+	m.longRun /= float64(len(returns))
+	if m.longRun == 0 {
+		return fmt.Errorf("forecast: GARCH needs a series that moves")
+	}
+
+	best := math.Inf(-1)
+	for a := 0.01; a <= 0.3; a += 0.01 {
+		for b := 0.5; a+b < 0.999; b += 0.01 {
+			ll, next := m.filter(returns, a, b)
+			if ll > best {
+				best = ll
+				m.alpha, m.beta, m.nextVar = a, b, next
+			}
+		}
+	}
+	m.omega = m.longRun * (1 - m.alpha - m.beta)
+	m.fitted = true
+	return nil
+}
+
+// filter runs the variance recursion and returns the log-likelihood and the
+// variance forecast for the next bar
+func (m *GARCH) filter(returns []float64, alpha, beta float64) (ll, next float64) {
+	omega := m.longRun * (1 - alpha - beta)
+	v := m.longRun
+	for _, r := range returns {
+		ll -= 0.5 * (math.Log(2*math.Pi*v) + r*r/v)
+		v = omega + alpha*r*r + beta*v
+	}
+	return ll, v
+}
+
+// ForecastVolatility returns the expected daily volatility (standard deviation
+// of log returns) for each of the next horizon bars
+func (m *GARCH) ForecastVolatility(horizon int) ([]float64, error) {
+	if !m.fitted {
+		return nil, ErrNotFitted
+	}
+	out := make([]float64, horizon)
+	persistence := m.alpha + m.beta
+	for h := range out {
+		v := m.longRun + math.Pow(persistence, float64(h))*(m.nextVar-m.longRun)
+		out[h] = math.Sqrt(v)
+	}
+	return out, nil
+}
@@ -0,0 +1,123 @@
+package forecast
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// GBM simulates geometric Brownian motion paths with the drift and volatility
+// of the fitted series. Its point forecast is the median path.
+type GBM struct {
+	Paths int   // number of simulated paths
+	Seed  int64 // random seed, fixed so the same data gives the same fan
+
+	mu, sigma float64 // daily drift and volatility of log returns
+	last      float64
+	fitted    bool
+}
+
+// NewGBM creates an unfitted model that simulates the given number of paths
+func NewGBM(paths int) *GBM {
+	return &GBM{Paths: paths, Seed: 1}
+}
+
+// String returns the model name and its estimated annualized drift and volatility
+func (m *GBM) String() string {
+	if !m.fitted {
+		return fmt.Sprintf("GBM(%d paths)", m.Paths)
+	}
+	return fmt.Sprintf("GBM(%d paths, μ=%.1f%% σ=%.1f%%)", m.Paths, m.mu*252*100, m.sigma*math.Sqrt(252)*100)
+}
+
+// Fit estimates drift and volatility from the log returns of series
+func (m *GBM) Fit(series []float64) error {
+	if len(series) < 3 {
+		return ErrTooShort
+	}
+	returns := make([]float64, 0, len(series)-1)
+	for i := 1; i < len(series); i++ {
+		if series[i-1] <= 0 || series[i] <= 0 {
+			return fmt.Errorf("forecast: GBM needs positive prices")
+		}
+		returns = append(returns, math.Log(series[i]/series[i-1]))
+	}
+	m.mu = mean(returns)
+	m.sigma = stddev(returns)
+	m.last = series[len(series)-1]
+	m.fitted = true
+	return nil
+}
+
+// Simulate returns Paths simulated price paths of length horizon
+func (m *GBM) Simulate(horizon int) ([][]float64, error) {
+	if !m.fitted {
+		return nil, ErrNotFitted
+	}
+	rng := rand.New(rand.NewSource(m.Seed))
+	paths := make([][]float64, m.Paths)
+	for i := range paths {
+		path := make([]float64, horizon)
+		price := m.last
+		// mu is already the mean log return, which includes the -σ²/2 term
+		for h := range path {
+			price *= math.Exp(m.mu + m.sigma*rng.NormFloat64())
+			path[h] = price
+		}
+		paths[i] = path
+	}
+	return paths, nil
+}
+
+// Quantiles returns, for each requested quantile in (0,1), the simulated price at every step
+func (m *GBM) Quantiles(horizon int, qs ...float64) ([][]float64, error) {
+	paths, err := m.Simulate(horizon)
+	if err != nil {
+		return nil, err
+	}
+	out := make([][]float64, len(qs))
+	for i := range out {
+		out[i] = make([]float64, horizon)
+	}
+	step := make([]float64, len(paths))
+	for h := 0; h < horizon; h++ {
+		for i, p := range paths {
+			step[i] = p[h]
+		}
+		sort.Float64s(step)
+		for i, q := range qs {
+			out[i][h] = quantile(step, q)
+		}
+	}
+	return out, nil
+}
+
+// Forecast returns the median simulated path
+func (m *GBM) Forecast(horizon int) ([]float64, error) {
+	q, err := m.Quantiles(horizon, 0.5)
+	if err != nil {
+		return nil, err
+	}
+	return q[0], nil
+}
+
+// Interval returns the simulated quantiles that contain the given share of paths
+func (m *GBM) Interval(horizon int, level float64) (lower, upper []float64, err error) {
+	q, err := m.Quantiles(horizon, (1-level)/2, (1+level)/2)
+	if err != nil {
+		return nil, nil, err
+	}
+	return q[0], q[1], nil
+}
+
+// quantile interpolates the q-th quantile of already sorted values
+func quantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	pos := q * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(pos-float64(lo))
+}
@@ -0,0 +1,125 @@
+package forecast
+
+import (
+	"fmt"
+	"math"
+)
+
+// HoltWinters is additive triple exponential smoothing with a seasonal period.
+// Smoothing factors left at zero are chosen by a grid search on the one-step error.
+type HoltWinters struct {
+	Period             int
+	Alpha, Beta, Gamma float64
+
+	alpha, beta, gamma float64 // factors actually used by the fit
+	level, trend       float64
+	season             []float64
+	n                  int
+	sigma2             float64
+	fitted             bool
+}
+
+// NewHoltWinters creates an unfitted model with the given season length in bars
+func NewHoltWinters(period int) *HoltWinters {
+	return &HoltWinters{Period: period}
+}
+
+// String returns the model name and its smoothing factors
+func (m *HoltWinters) String() string {
+	if !m.fitted {
+		return fmt.Sprintf("Holt-Winters(%d)", m.Period)
+	}
+	return fmt.Sprintf("Holt-Winters(%d, α=%.1f β=%.1f γ=%.1f)", m.Period, m.alpha, m.beta, m.gamma)
+}
+
+// Fit estimates level, trend and seasonal components from series
+func (m *HoltWinters) Fit(series []float64) error {
+	if m.Period < 2 {
+		return fmt.Errorf("forecast: invalid Holt-Winters period %d", m.Period)
+	}
+	if len(series) < 2*m.Period+1 {
+		return ErrTooShort
+	}
+
+	m.alpha, m.beta, m.gamma = m.Alpha, m.Beta, m.Gamma
+	if m.Alpha == 0 && m.Beta == 0 && m.Gamma == 0 {
+		best := math.Inf(1)
+		for a := 0.1; a < 0.95; a += 0.1 {
+			for b := 0.1; b < 0.95; b += 0.1 {
+				for g := 0.1; g < 0.95; g += 0.1 {
+					if sse, _, _, _ := m.run(series, a, b, g); sse < best {
+						best = sse
+						m.alpha, m.beta, m.gamma = a, b, g
+					}
+				}
+			}
+		}
+	}
+
+	sse, level, trend, season := m.run(series, m.alpha, m.beta, m.gamma)
+	m.level, m.trend, m.season = level, trend, season
+	m.n = len(series)
+	m.sigma2 = sse / float64(len(series)-m.Period)
+	m.fitted = true
+	return nil
+}
+
+// run smooths series with the given factors and returns the squared one-step
+// error along with the final state
+func (m *HoltWinters) run(series []float64, alpha, beta, gamma float64) (sse, level, trend float64, season []float64) {
+	p := m.Period
+	level = mean(series[:p])
+	trend = (mean(series[p:2*p]) - level) / float64(p)
+	season = make([]float64, p)
+	for i := 0; i < p; i++ {
+		season[i] = series[i] - level
+	}
+
+	for t := p; t < len(series); t++ {
+		s := season[t%p]
+		err := series[t] - (level + trend + s)
+		sse += err * err
+
+		next := alpha*(series[t]-s) + (1-alpha)*(level+trend)
+		trend = beta*(next-level) + (1-beta)*trend
+		season[t%p] = gamma*(series[t]-next) + (1-gamma)*s
+		level = next
+	}
+	return sse, level, trend, season
+}
+
+// Forecast predicts the next horizon values of the fitted series
+func (m *HoltWinters) Forecast(horizon int) ([]float64, error) {
+	if !m.fitted {
+		return nil, ErrNotFitted
+	}
+
+	out := make([]float64, horizon)
+	for h := range out {
+		out[h] = m.level + float64(h+1)*m.trend + m.season[(m.n+h)%m.Period]
+	}
+	return out, nil
+}
+
+// Interval returns normal prediction bounds using the additive Holt-Winters
+// forecast variance
+func (m *HoltWinters) Interval(horizon int, level float64) (lower, upper []float64, err error) {
+	points, err := m.Forecast(horizon)
+	if err != nil {
+		return nil, nil, err
+	}
+	stderr := make([]float64, horizon)
+	total := 1.0
+	for h := range stderr {
+		if h > 0 {
+			c := m.alpha * (1 + float64(h)*m.beta)
+			if h%m.Period == 0 {
+				c += m.gamma
+			}
+			total += c * c
+		}
+		stderr[h] = math.Sqrt(m.sigma2 * total)
+	}
+	lower, upper = normalInterval(points, stderr, level)
+	return lower, upper, nil
+}
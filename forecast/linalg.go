@@ -0,0 +1,109 @@
+package forecast
+
+import (
+	"errors"
+	"math"
+)
+
+// errSingular is returned when a regression has no unique solution
+var errSingular = errors.New("forecast: singular regression matrix")
+
+// leastSquares solves min ||X b - y|| through the normal equations
+func leastSquares(x [][]float64, y []float64) ([]float64, error) {
+	return ridge(x, y, nil)
+}
+
+// ridge solves min ||X b - y||² + Σ penalty[i]·b[i]², a nil penalty gives
+// ordinary least squares
+func ridge(x [][]float64, y []float64, penalty []float64) ([]float64, error) {
+	if len(x) == 0 {
+		return nil, errSingular
+	}
+	k := len(x[0])
+	if k == 0 {
+		return []float64{}, nil
+	}
+
+	// Build X'X and X'y as an augmented matrix
+	a := make([][]float64, k)
+	for i := range a {
+		a[i] = make([]float64, k+1)
+	}
+	for r, row := range x {
+		for i := 0; i < k; i++ {
+			for j := i; j < k; j++ {
+				a[i][j] += row[i] * row[j]
+			}
+			a[i][k] += row[i] * y[r]
+		}
+	}
+	for i := 0; i < k; i++ {
+		for j := 0; j < i; j++ {
+			a[i][j] = a[j][i]
+		}
+		if penalty != nil {
+			a[i][i] += penalty[i]
+		}
+	}
+
+	return solve(a)
+}
+
+// solve performs Gaussian elimination with partial pivoting on an augmented matrix
+func solve(a [][]float64) ([]float64, error) {
+	k := len(a)
+	for col := 0; col < k; col++ {
+		pivot := col
+		for r := col + 1; r < k; r++ {
+			if math.Abs(a[r][col]) > math.Abs(a[pivot][col]) {
+				pivot = r
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return nil, errSingular
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+
+		for r := col + 1; r < k; r++ {
+			f := a[r][col] / a[col][col]
+			for c := col; c <= k; c++ {
+				a[r][c] -= f * a[col][c]
+			}
+		}
+	}
+
+	b := make([]float64, k)
+	for i := k - 1; i >= 0; i-- {
+		sum := a[i][k]
+		for j := i + 1; j < k; j++ {
+			sum -= a[i][j] * b[j]
+		}
+		b[i] = sum / a[i][i]
+	}
+	return b, nil
+}
+
+// stddev returns the sample standard deviation of values
+func stddev(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+	m := mean(values)
+	ss := 0.0
+	for _, v := range values {
+		ss += (v - m) * (v - m)
+	}
+	return math.Sqrt(ss / float64(len(values)-1))
+}
+
+// mean returns the arithmetic mean of values
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
@@ -0,0 +1,205 @@
+package forecast
+
+import (
+	"fmt"
+	"math"
+)
+
+// Drift extends the straight line from the first to the last value of the series
+type Drift struct {
+	series []float64
+	slope  float64
+	sigma2 float64
+}
+
+// NewDrift creates an unfitted drift model
+func NewDrift() *Drift {
+	return &Drift{}
+}
+
+// String returns the model name and the fitted slope per bar
+func (m *Drift) String() string {
+	if m.series == nil {
+		return "Drift"
+	}
+	return fmt.Sprintf("Drift(%+.3f/day)", m.slope)
+}
+
+// Fit estimates the average change per bar
+func (m *Drift) Fit(series []float64) error {
+	n := len(series)
+	if n < 3 {
+		return ErrTooShort
+	}
+	m.slope = (series[n-1] - series[0]) / float64(n-1)
+	ss := 0.0
+	for t := 1; t < n; t++ {
+		e := series[t] - series[t-1] - m.slope
+		ss += e * e
+	}
+	m.sigma2 = ss / float64(n-2)
+	m.series = append([]float64(nil), series...)
+	return nil
+}
+
+// Forecast continues the series along the drift line
+func (m *Drift) Forecast(horizon int) ([]float64, error) {
+	if m.series == nil {
+		return nil, ErrNotFitted
+	}
+	last := m.series[len(m.series)-1]
+	out := make([]float64, horizon)
+	for h := range out {
+		out[h] = last + float64(h+1)*m.slope
+	}
+	return out, nil
+}
+
+// Interval returns normal prediction bounds that include the slope's uncertainty
+func (m *Drift) Interval(horizon int, level float64) (lower, upper []float64, err error) {
+	points, err := m.Forecast(horizon)
+	if err != nil {
+		return nil, nil, err
+	}
+	n := float64(len(m.series) - 1)
+	stderr := make([]float64, horizon)
+	for h := range stderr {
+		steps := float64(h + 1)
+		stderr[h] = math.Sqrt(m.sigma2 * steps * (1 + steps/n))
+	}
+	lower, upper = normalInterval(points, stderr, level)
+	return lower, upper, nil
+}
+
+// Naive repeats the last value of the series
+type Naive struct {
+	last   float64
+	sigma2 float64
+	fitted bool
+}
+
+// NewNaive creates an unfitted last value model
+func NewNaive() *Naive {
+	return &Naive{}
+}
+
+// String returns the model name
+func (m *Naive) String() string {
+	return "Naive"
+}
+
+// Fit remembers the last value and the variance of one step changes
+func (m *Naive) Fit(series []float64) error {
+	n := len(series)
+	if n < 2 {
+		return ErrTooShort
+	}
+	ss := 0.0
+	for t := 1; t < n; t++ {
+		d := series[t] - series[t-1]
+		ss += d * d
+	}
+	m.sigma2 = ss / float64(n-1)
+	m.last = series[n-1]
+	m.fitted = true
+	return nil
+}
+
+// Forecast repeats the last value
+func (m *Naive) Forecast(horizon int) ([]float64, error) {
+	if !m.fitted {
+		return nil, ErrNotFitted
+	}
+	out := make([]float64, horizon)
+	for h := range out {
+		out[h] = m.last
+	}
+	return out, nil
+}
+
+// Interval returns random walk bounds that widen with the square root of the horizon
+func (m *Naive) Interval(horizon int, level float64) (lower, upper []float64, err error) {
+	points, err := m.Forecast(horizon)
+	if err != nil {
+		return nil, nil, err
+	}
+	stderr := make([]float64, horizon)
+	for h := range stderr {
+		stderr[h] = math.Sqrt(m.sigma2 * float64(h+1))
+	}
+	lower, upper = normalInterval(points, stderr, level)
+	return lower, upper, nil
+}
+
+// LinearTrend fits an ordinary least squares line through the series and extends it
+type LinearTrend struct {
+	intercept, slope float64
+	n                int
+	sigma2           float64
+	fitted           bool
+}
+
+// NewLinearTrend creates an unfitted linear regression model
+func NewLinearTrend() *LinearTrend {
+	return &LinearTrend{}
+}
+
+// String returns the model name and the fitted slope per bar
+func (m *LinearTrend) String() string {
+	if !m.fitted {
+		return "Linear trend"
+	}
+	return fmt.Sprintf("Linear trend(%+.3f/day)", m.slope)
+}
+
+// Fit regresses the series on time
+func (m *LinearTrend) Fit(series []float64) error {
+	n := len(series)
+	if n < 3 {
+		return ErrTooShort
+	}
+	x := make([][]float64, n)
+	for t := range x {
+		x[t] = []float64{1, float64(t)}
+	}
+	coef, err := leastSquares(x, series)
+	if err != nil {
+		return err
+	}
+	m.intercept, m.slope = coef[0], coef[1]
+	ss := 0.0
+	for t, y := range series {
+		e := y - m.intercept - m.slope*float64(t)
+		ss += e * e
+	}
+	m.sigma2 = ss / float64(n-2)
+	m.n = n
+	m.fitted = true
+	return nil
+}
+
+// Forecast extends the fitted line
+func (m *LinearTrend) Forecast(horizon int) ([]float64, error) {
+	if !m.fitted {
+		return nil, ErrNotFitted
+	}
+	out := make([]float64, horizon)
+	for h := range out {
+		out[h] = m.intercept + m.slope*float64(m.n+h)
+	}
+	return out, nil
+}
+
+// Interval returns normal bounds from the regression residual variance
+func (m *LinearTrend) Interval(horizon int, level float64) (lower, upper []float64, err error) {
+	points, err := m.Forecast(horizon)
+	if err != nil {
+		return nil, nil, err
+	}
+	stderr := make([]float64, horizon)
+	for h := range stderr {
+		stderr[h] = math.Sqrt(m.sigma2)
+	}
+	lower, upper = normalInterval(points, stderr, level)
+	return lower, upper, nil
+}
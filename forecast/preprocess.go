@@ -0,0 +1,140 @@
+package forecast
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Preprocessed fits Model to a transformed copy of the series and maps its
+// forecasts back to the original scale
+type Preprocessed struct {
+	Model      Forecaster
+	Log        bool    // model log prices, so percentage moves become additive
+	Difference bool    // model the changes between bars instead of levels
+	Winsorize  float64 // clip changes beyond this tail quantile, e.g. 0.01; 0 disables
+
+	last   float64 // last transformed level, the base the differences are summed from
+	fitted bool
+}
+
+// NewPreprocessed wraps model with the given preprocessing steps
+func NewPreprocessed(model Forecaster, log, difference bool, winsorize float64) *Preprocessed {
+	return &Preprocessed{Model: model, Log: log, Difference: difference, Winsorize: winsorize}
+}
+
+// String returns the wrapped model followed by the steps applied, e.g. "Drift [log, differenced]"
+func (m *Preprocessed) String() string {
+	var steps []string
+	if m.Log {
+		steps = append(steps, "log")
+	}
+	if m.Winsorize > 0 {
+		steps = append(steps, fmt.Sprintf("winsorized %g%%", m.Winsorize*100))
+	}
+	if m.Difference {
+		steps = append(steps, "differenced")
+	}
+	if len(steps) == 0 {
+		return m.Model.String()
+	}
+	return fmt.Sprintf("%s [%s]", m.Model, strings.Join(steps, ", "))
+}
+
+// Fit transforms the series and fits the wrapped model to it
+func (m *Preprocessed) Fit(series []float64) error {
+	if m.Winsorize < 0 || m.Winsorize >= 0.5 {
+		return fmt.Errorf("forecast: invalid winsorization tail %g", m.Winsorize)
+	}
+	if len(series) < 2 {
+		return ErrTooShort
+	}
+
+	z := append([]float64(nil), series...)
+	if m.Log {
+		for i, v := range z {
+			if v <= 0 {
+				return fmt.Errorf("forecast: log transform needs positive prices")
+			}
+			z[i] = math.Log(v)
+		}
+	}
+
+	if m.Winsorize > 0 {
+		changes := difference(z, 1)
+		sorted := append([]float64(nil), changes...)
+		sort.Float64s(sorted)
+		lo, hi := quantile(sorted, m.Winsorize), quantile(sorted, 1-m.Winsorize)
+
+		// Rebuild the levels backwards so the latest value, where the
+		// forecast starts, stays as observed
+		for i := len(changes) - 1; i >= 0; i-- {
+			z[i] = z[i+1] - math.Max(lo, math.Min(hi, changes[i]))
+		}
+	}
+
+	m.last = z[len(z)-1]
+	if m.Difference {
+		z = difference(z, 1)
+	}
+	if err := m.Model.Fit(z); err != nil {
+		return err
+	}
+	m.fitted = true
+	return nil
+}
+
+// Forecast predicts with the wrapped model and undoes the transform
+func (m *Preprocessed) Forecast(horizon int) ([]float64, error) {
+	if !m.fitted {
+		return nil, ErrNotFitted
+	}
+	f, err := m.Model.Forecast(horizon)
+	if err != nil {
+		return nil, err
+	}
+	if m.Difference {
+		f = integrate([]float64{m.last}, f, 1)
+	}
+	return m.exp(f), nil
+}
+
+// Interval maps the wrapped model's bounds back to the original scale. When
+// differencing, the per-step half widths are added up as independent errors.
+func (m *Preprocessed) Interval(horizon int, level float64) (lower, upper []float64, err error) {
+	if !m.fitted {
+		return nil, nil, ErrNotFitted
+	}
+	lower, upper, err = m.Model.Interval(horizon, level)
+	if err != nil {
+		return nil, nil, err
+	}
+	if m.Difference {
+		points, err := m.Model.Forecast(horizon)
+		if err != nil {
+			return nil, nil, err
+		}
+		center := integrate([]float64{m.last}, points, 1)
+		total := 0.0
+		for i := range lower {
+			half := (upper[i] - lower[i]) / 2
+			total += half * half
+			lower[i] = center[i] - math.Sqrt(total)
+			upper[i] = center[i] + math.Sqrt(total)
+		}
+	}
+	return m.exp(lower), m.exp(upper), nil
+}
+
+// exp undoes the log transform, if enabled
+func (m *Preprocessed) exp(values []float64) []float64 {
+	if !m.Log {
+		return values
+	}
+	out := make([]float64, len(values))
+	for i, v := range values {
+		out[i] = math.Exp(v)
+	}
+	return out
+}
@@ -0,0 +1,124 @@
+package forecast
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Remote forwards the series to an HTTP prediction service, so teams can run
+// their own models centrally. The service is sent a JSON request
+//
+//	{"series": [...], "horizon": 30, "level": 0.95}
+//
+// and must answer with
+//
+//	{"predictions": [...], "lower": [...], "upper": [...]}
+//
+// where lower and upper are only needed when level is present.
+type Remote struct {
+	URL    string
+	Client *http.Client
+
+	series []float64
+}
+
+// remoteRequest and remoteResponse are the JSON bodies exchanged with the service
+type remoteRequest struct {
+	Series  []float64 `json:"series"`
+	Horizon int       `json:"horizon"`
+	Level   float64   `json:"level,omitempty"`
+}
+
+type remoteResponse struct {
+	Predictions []float64 `json:"predictions"`
+	Lower       []float64 `json:"lower"`
+	Upper       []float64 `json:"upper"`
+}
+
+// NewRemote creates a model backed by the prediction service at rawURL
+func NewRemote(rawURL string) *Remote {
+	return &Remote{URL: rawURL, Client: &http.Client{Timeout: 60 * time.Second}}
+}
+
+// String returns the model name and the service host
+func (m *Remote) String() string {
+	u, err := url.Parse(m.URL)
+	if err != nil || u.Host == "" {
+		return "Remote"
+	}
+	return fmt.Sprintf("Remote(%s)", u.Host)
+}
+
+// Fit keeps the series to send along with each request; the service does the fitting
+func (m *Remote) Fit(series []float64) error {
+	if m.URL == "" {
+		return errors.New("forecast: no prediction service URL configured")
+	}
+	if len(series) < 2 {
+		return ErrTooShort
+	}
+	m.series = append([]float64(nil), series...)
+	return nil
+}
+
+// Forecast asks the service for the next horizon values
+func (m *Remote) Forecast(horizon int) ([]float64, error) {
+	resp, err := m.post(remoteRequest{Series: m.series, Horizon: horizon})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Predictions, nil
+}
+
+// Interval asks the service for bounds at the given level
+func (m *Remote) Interval(horizon int, level float64) (lower, upper []float64, err error) {
+	resp, err := m.post(remoteRequest{Series: m.series, Horizon: horizon, Level: level})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(resp.Lower) != horizon || len(resp.Upper) != horizon {
+		return nil, nil, fmt.Errorf("forecast: prediction service returned no %.0f%% interval", level*100)
+	}
+	return resp.Lower, resp.Upper, nil
+}
+
+// post sends one request to the service and checks the shape of the answer
+func (m *Remote) post(req remoteRequest) (remoteResponse, error) {
+	if m.series == nil {
+		return remoteResponse{}, ErrNotFitted
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return remoteResponse{}, err
+	}
+
+	client := m.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	httpResp, err := client.Post(m.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return remoteResponse{}, fmt.Errorf("forecast: prediction service: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(httpResp.Body, 512))
+		return remoteResponse{}, fmt.Errorf("forecast: prediction service returned %s: %s", httpResp.Status, bytes.TrimSpace(msg))
+	}
+
+	var resp remoteResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return remoteResponse{}, fmt.Errorf("forecast: decoding prediction service response: %w", err)
+	}
+	if len(resp.Predictions) != req.Horizon {
+		return remoteResponse{}, fmt.Errorf("forecast: prediction service returned %d predictions, want %d", len(resp.Predictions), req.Horizon)
+	}
+	return resp, nil
+}
@@ -0,0 +1,134 @@
+package forecast
+
+import (
+	"fmt"
+	"math"
+)
+
+// Decomposition splits a series into trend, seasonal and residual parts that add up to it
+type Decomposition struct {
+	Trend    []float64
+	Seasonal []float64
+	Residual []float64
+}
+
+// STL decomposes series with the seasonal-trend decomposition by LOESS of
+// Cleveland et al., using the default inner loop without robustness weights
+func STL(series []float64, period int) (Decomposition, error) {
+	n := len(series)
+	if period < 2 {
+		return Decomposition{}, fmt.Errorf("forecast: invalid STL period %d", period)
+	}
+	if n < 2*period+1 {
+		return Decomposition{}, ErrTooShort
+	}
+
+	// Window sizes recommended in the STL paper, forced to be odd
+	seasonalWindow := 7
+	lowPassWindow := odd(period + 1)
+	trendWindow := odd(int(math.Ceil(1.5 * float64(period) / (1 - 1.5/float64(seasonalWindow)))))
+
+	trend := make([]float64, n)
+	seasonal := make([]float64, n)
+	detrended := make([]float64, n)
+	for iter := 0; iter < 2; iter++ {
+		for i := range series {
+			detrended[i] = series[i] - trend[i]
+		}
+
+		// Smooth each cycle subseries, e.g. all Mondays, separately
+		cycle := make([]float64, n)
+		for phase := 0; phase < period; phase++ {
+			var sub []float64
+			for i := phase; i < n; i += period {
+				sub = append(sub, detrended[i])
+			}
+			smooth := loess(sub, seasonalWindow)
+			for j, i := 0, phase; i < n; j, i = j+1, i+period {
+				cycle[i] = smooth[j]
+			}
+		}
+
+		// Remove any trend the cycle smoothing picked up
+		low := loess(movingAverage(movingAverage(movingAverage(cycle, period), period), 3), lowPassWindow)
+		for i := range seasonal {
+			seasonal[i] = cycle[i] - low[i]
+		}
+
+		deseasoned := make([]float64, n)
+		for i := range series {
+			deseasoned[i] = series[i] - seasonal[i]
+		}
+		trend = loess(deseasoned, trendWindow)
+	}
+
+	residual := make([]float64, n)
+	for i := range series {
+		residual[i] = series[i] - trend[i] - seasonal[i]
+	}
+	return Decomposition{Trend: trend, Seasonal: seasonal, Residual: residual}, nil
+}
+
+// loess smooths y with locally weighted linear regression over the nearest q points
+func loess(y []float64, q int) []float64 {
+	n := len(y)
+	out := make([]float64, n)
+	if q > n {
+		q = n
+	}
+	for i := range y {
+		lo := i - q/2
+		if lo < 0 {
+			lo = 0
+		}
+		if lo+q > n {
+			lo = n - q
+		}
+		hi := lo + q - 1
+		maxDist := math.Max(float64(i-lo), float64(hi-i)) + 1
+
+		var sw, sx, sy, sxx, sxy float64
+		for j := lo; j <= hi; j++ {
+			d := math.Abs(float64(j-i)) / maxDist
+			w := math.Pow(1-d*d*d, 3)
+			x := float64(j - i)
+			sw += w
+			sx += w * x
+			sy += w * y[j]
+			sxx += w * x * x
+			sxy += w * x * y[j]
+		}
+		denom := sw*sxx - sx*sx
+		if math.Abs(denom) < 1e-12 {
+			out[i] = sy / sw
+			continue
+		}
+		// Intercept of the local line is its value at x = 0, the current point
+		out[i] = (sy*sxx - sx*sxy) / denom
+	}
+	return out
+}
+
+// movingAverage returns the centred moving average of y, shrinking the window at the ends
+func movingAverage(y []float64, window int) []float64 {
+	out := make([]float64, len(y))
+	half := window / 2
+	for i := range y {
+		lo, hi := i-half, i+window-half-1
+		if lo < 0 {
+			lo = 0
+		}
+		if hi >= len(y) {
+			hi = len(y) - 1
+		}
+		out[i] = mean(y[lo : hi+1])
+	}
+	return out
+}
+
+func odd(n int) int {
+	if n%2 == 0 {
+		return n + 1
+	}
+	return n
+}
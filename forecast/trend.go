@@ -0,0 +1,173 @@
+package forecast
+
+import (
+	"fmt"
+	"math"
+)
+
+// TrendSeasonal models a series as a piecewise-linear trend plus Fourier seasonal
+// terms, in the style of Prophet. Candidate changepoints are spread over the
+// first 80% of the series and shrunk towards zero so only real slope changes
+// survive the fit.
+type TrendSeasonal struct {
+	Changepoints int     // number of candidate changepoints
+	Penalty      float64 // shrinkage applied to changepoint slope changes
+
+	n       int
+	cps     []float64 // changepoint positions in scaled time
+	coef    []float64
+	yMean   float64
+	yScale  float64
+	periods []seasonality
+	sigma2  float64
+	fitted  bool
+}
+
+// seasonality is one Fourier series with a period in bars
+type seasonality struct {
+	period float64
+	order  int
+}
+
+// NewTrendSeasonal creates an unfitted model with 25 candidate changepoints
+func NewTrendSeasonal() *TrendSeasonal {
+	return &TrendSeasonal{Changepoints: 25, Penalty: 0.05}
+}
+
+// String returns the model name and the number of changepoints it kept
+func (m *TrendSeasonal) String() string {
+	if !m.fitted {
+		return "Trend+Seasonal"
+	}
+	return fmt.Sprintf("Trend+Seasonal(%d changepoints)", len(m.DetectedChangepoints()))
+}
+
+// Fit estimates the trend, its changepoints and the seasonal terms from series
+func (m *TrendSeasonal) Fit(series []float64) error {
+	n := len(series)
+	if n < 2*m.Changepoints+10 || n < 20 {
+		return ErrTooShort
+	}
+
+	// Weekly seasonality is always fitted, yearly only once there are two years to learn it from
+	m.periods = []seasonality{{period: 5, order: 2}}
+	if n >= 2*252 {
+		m.periods = append(m.periods, seasonality{period: 252, order: 4})
+	}
+
+	m.cps = make([]float64, m.Changepoints)
+	for i := range m.cps {
+		m.cps[i] = 0.8 * float64(i+1) / float64(m.Changepoints+1)
+	}
+
+	// Standardise so the penalty means the same thing for any price level
+	m.yMean = mean(series)
+	m.yScale = stddev(series)
+	if m.yScale == 0 {
+		m.yScale = 1
+	}
+	m.n = n
+
+	x := make([][]float64, n)
+	y := make([]float64, n)
+	for t := range series {
+		x[t] = m.features(t)
+		y[t] = (series[t] - m.yMean) / m.yScale
+	}
+
+	// Iteratively reweighted ridge approximates the sparse (Laplace prior) fit
+	// Prophet uses, so most candidate changepoints end up at zero
+	penalty := make([]float64, len(x[0]))
+	for i := range m.cps {
+		penalty[2+i] = m.Penalty
+	}
+	var coef []float64
+	for iter := 0; iter < 10; iter++ {
+		var err error
+		coef, err = ridge(x, y, penalty)
+		if err != nil {
+			return err
+		}
+		for i := range m.cps {
+			penalty[2+i] = m.Penalty / (math.Abs(coef[2+i]) + 1e-3)
+		}
+	}
+	m.coef = coef
+
+	ss := 0.0
+	for t := range series {
+		e := series[t] - m.predict(t)
+		ss += e * e
+	}
+	m.sigma2 = ss / float64(n)
+	m.fitted = true
+	return nil
+}
+
+// features returns the regression row for bar t: intercept, slope,
+// changepoint hinges and the seasonal sine/cosine pairs
+func (m *TrendSeasonal) features(t int) []float64 {
+	s := float64(t) / float64(m.n)
+	row := []float64{1, s}
+	for _, c := range m.cps {
+		row = append(row, math.Max(0, s-c))
+	}
+	for _, p := range m.periods {
+		for k := 1; k <= p.order; k++ {
+			angle := 2 * math.Pi * float64(k) * float64(t) / p.period
+			row = append(row, math.Sin(angle), math.Cos(angle))
+		}
+	}
+	return row
+}
+
+// predict evaluates the fitted model at bar t in price units
+func (m *TrendSeasonal) predict(t int) float64 {
+	return dot(m.coef, m.features(t))*m.yScale + m.yMean
+}
+
+// DetectedChangepoints returns the bar indices where the trend slope changed
+// noticeably, measured against the largest change the fit found
+func (m *TrendSeasonal) DetectedChangepoints() []int {
+	if !m.fitted {
+		return nil
+	}
+	largest := 0.0
+	for i := range m.cps {
+		largest = math.Max(largest, math.Abs(m.coef[2+i]))
+	}
+	var out []int
+	for i, c := range m.cps {
+		if largest > 0 && math.Abs(m.coef[2+i]) >= 0.25*largest {
+			out = append(out, int(c*float64(m.n)))
+		}
+	}
+	return out
+}
+
+// Forecast extends the final trend segment and the seasonal terms horizon bars ahead
+func (m *TrendSeasonal) Forecast(horizon int) ([]float64, error) {
+	if !m.fitted {
+		return nil, ErrNotFitted
+	}
+	out := make([]float64, horizon)
+	for h := range out {
+		out[h] = m.predict(m.n + h)
+	}
+	return out, nil
+}
+
+// Interval returns normal prediction bounds from the in-sample residual
+// variance, which doesn't grow with the horizon since the trend is held fixed
+func (m *TrendSeasonal) Interval(horizon int, level float64) (lower, upper []float64, err error) {
+	points, err := m.Forecast(horizon)
+	if err != nil {
+		return nil, nil, err
+	}
+	stderr := make([]float64, horizon)
+	for h := range stderr {
+		stderr[h] = math.Sqrt(m.sigma2)
+	}
+	lower, upper = normalInterval(points, stderr, level)
+	return lower, upper, nil
+}
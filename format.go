@@ -0,0 +1,83 @@
+package main
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2/lang"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// numbers prints numbers with the digit grouping and decimal mark of the user's locale
+var numbers = message.NewPrinter(systemLanguage())
+
+// systemLanguage returns the language of the user's locale, falling back to
+// US English. Fyne appends the script, e.g. "de-DE-Latn", which is dropped
+// as it comes after the region
+func systemLanguage() language.Tag {
+	tag, err := language.Parse(strings.ReplaceAll(lang.SystemLocale().LanguageString(), "_", "-"))
+	if err != nil {
+		return language.AmericanEnglish
+	}
+	return tag
+}
+
+// symbolCurrency returns the currency symbol is listed in. Tiingo end of day
+// prices are US listings, so anything the settings don't override is USD
+func symbolCurrency(symbol string) currency.Unit {
+	if code, ok := settings.Get().Currencies[symbol]; ok {
+		if cur, err := currency.ParseISO(code); err == nil {
+			return cur
+		}
+	}
+	return currency.USD
+}
+
+// formatPrice formats v in cur, e.g. "$1,234.50" or "¥1,235", with the
+// currency's usual number of decimals
+func formatPrice(v float64, cur currency.Unit) string {
+	sign := ""
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	scale, _ := currency.Standard.Rounding(cur)
+	return sign + numbers.Sprint(currency.NarrowSymbol(cur)) + numbers.Sprint(number.Decimal(v, number.Scale(scale)))
+}
+
+// formatChange formats a price move and its percentage, e.g. "+$1.20 (+0.85%)"
+func formatChange(diff, pct float64, cur currency.Unit) string {
+	price := formatPrice(diff, cur)
+	if diff >= 0 {
+		price = "+" + price
+	}
+	return price + " (" + formatPercent(pct) + ")"
+}
+
+// formatPercent formats a signed percentage, e.g. "-2.31%"
+func formatPercent(v float64) string {
+	return numbers.Sprintf("%+.2f%%", v)
+}
+
+// formatNumber formats v with the given number of decimals
+func formatNumber(v float64, decimals int) string {
+	return numbers.Sprint(number.Decimal(v, number.Scale(decimals)))
+}
+
+// formatCompact shortens a large number such as a volume or market cap, e.g.
+// 12500000 to "12.5M"
+func formatCompact(v float64) string {
+	suffix := ""
+	switch {
+	case v >= 1e12:
+		v, suffix = v/1e12, "T"
+	case v >= 1e9:
+		v, suffix = v/1e9, "B"
+	case v >= 1e6:
+		v, suffix = v/1e6, "M"
+	case v >= 1e3:
+		v, suffix = v/1e3, "K"
+	}
+	return numbers.Sprint(number.Decimal(v, number.MaxFractionDigits(2))) + suffix
+}
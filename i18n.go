@@ -0,0 +1,106 @@
+package main
+
+import (
+	"embed"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+	"gonum.org/v1/plot"
+)
+
+// translations holds the UI text in each supported language, keyed by the
+// English text. Fyne picks the file matching the user's locale
+//
+//go:embed translations
+var translations embed.FS
+
+// loadTranslations adds the app's translations to Fyne's own
+func loadTranslations() {
+	if err := lang.AddTranslationsFS(translations, "translations"); err != nil {
+		log.Println("Error loading translations:", err)
+	}
+}
+
+// localSelect is a select showing its options translated, while callers
+// deal in the English values the settings store
+type localSelect struct {
+	*widget.Select
+	values []string
+}
+
+// newLocalSelect creates a select of values calling changed with the value picked
+func newLocalSelect(values []string, changed func(value string)) *localSelect {
+	s := &localSelect{values: values}
+	labels := make([]string, len(values))
+	for i, v := range values {
+		labels[i] = lang.L(v)
+	}
+	s.Select = widget.NewSelect(labels, func(label string) {
+		if changed != nil {
+			changed(s.Value())
+		}
+	})
+	return s
+}
+
+// Value returns the value selected, empty if none is
+func (s *localSelect) Value() string {
+	if i := s.SelectedIndex(); i >= 0 && i < len(s.values) {
+		return s.values[i]
+	}
+	return ""
+}
+
+// SetValue selects value
+func (s *localSelect) SetValue(value string) {
+	if i := slices.Index(s.values, value); i >= 0 {
+		s.SetSelectedIndex(i)
+	}
+}
+
+// formatDate formats t with a Go layout such as "Jan 2", first translated to
+// the order the user's locale writes dates in and then with the month and
+// weekday names translated
+func formatDate(t time.Time, layout string) string {
+	text := t.Format(lang.L(layout))
+	if month := t.Month().String()[:3]; strings.Contains(text, month) {
+		text = strings.Replace(text, month, lang.L(month), 1)
+	}
+	if day := t.Weekday().String()[:3]; strings.Contains(text, day) {
+		text = strings.Replace(text, day, lang.L(day), 1)
+	}
+	return text
+}
+
+// numberTicks labels an axis with numbers in the user's locale
+type numberTicks struct{}
+
+// Ticks implements plot.Ticker
+func (numberTicks) Ticks(min, max float64) []plot.Tick {
+	ticks := plot.DefaultTicks{}.Ticks(min, max)
+	for i, tick := range ticks {
+		if tick.Label == "" || strings.ContainsAny(tick.Label, "eE") {
+			continue // unlabelled, or too large or small to write out
+		}
+		decimals := 0
+		if dot := strings.IndexByte(tick.Label, '.'); dot >= 0 {
+			decimals = len(tick.Label) - dot - 1
+		}
+		ticks[i].Label = formatNumber(tick.Value, decimals)
+	}
+	return ticks
+}
+
+// formatDay formats a provider date such as "2024-01-02T00:00:00Z" the way
+// the user's locale writes dates, leaving it as it is if it doesn't parse
+func formatDay(date string) string {
+	t, err := time.Parse("2006-01-02", shortDate(date))
+	if err != nil {
+		return date
+	}
+	return formatDate(t, "2006-01-02")
+}
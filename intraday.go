@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"time"
+	_ "time/tzdata" // market hours need America/New_York on systems without a zoneinfo database
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+)
+
+// Tiingo IEX endpoint resampled to hourly bars
+const intradayURL = "https://api.tiingo.com/iex/%s/prices?startDate=%s&resampleFreq=1hour&token=%s"
+
+// IntradayBar holds one resampled bar from the IEX endpoint
+type IntradayBar struct {
+	Date  time.Time `json:"date"`
+	Open  float64   `json:"open"`
+	Close float64   `json:"close"`
+}
+
+// marketLocation is the time zone US market hours are defined in
+var marketLocation, _ = time.LoadLocation("America/New_York")
+
+// marketOpen reports whether t falls within regular US trading hours
+func marketOpen(t time.Time) bool {
+	t = t.In(marketLocation)
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+	minutes := t.Hour()*60 + t.Minute()
+	return minutes >= 9*60+30 && minutes < 16*60
+}
+
+// fetchIntradayData retrieves today's hourly bars for a symbol from Tiingo IEX
+func fetchIntradayData(symbol string) ([]IntradayBar, error) {
+	day := time.Now().In(marketLocation).Format("2006-01-02")
+	url := fmt.Sprintf(intradayURL, symbol, day, settings.Get().APIKey)
+	usage.Record()
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var bars []IntradayBar
+	if err := json.Unmarshal(body, &bars); err != nil {
+		return nil, err
+	}
+
+	return bars, nil
+}
+
+// hourlyChanges returns the percent change of each bar from its open
+func hourlyChanges(bars []IntradayBar) []float64 {
+	changes := make([]float64, 0, len(bars))
+	for _, b := range bars {
+		if b.Open == 0 {
+			continue
+		}
+		changes = append(changes, (b.Close-b.Open)/b.Open*100)
+	}
+	return changes
+}
+
+// Heat strip block sizes, beside the quote of a tab and in a watchlist row
+var (
+	quoteHeatBlock = fyne.NewSize(8, 14)
+	rowHeatBlock   = fyne.NewSize(5, 10)
+)
+
+// heatStrip draws one small block per hour, green for gains and red for losses
+type heatStrip struct {
+	box   *fyne.Container
+	block fyne.Size
+}
+
+// newHeatStrip creates an empty heat strip drawing blocks of the given size
+func newHeatStrip(block fyne.Size) *heatStrip {
+	return &heatStrip{box: container.NewHBox(), block: block}
+}
+
+// SetChanges redraws the strip from hourly percent changes
+func (h *heatStrip) SetChanges(changes []float64) {
+	blocks := make([]fyne.CanvasObject, len(changes))
+	for i, c := range changes {
+		r := canvas.NewRectangle(heatColor(c))
+		r.SetMinSize(h.block)
+		blocks[i] = r
+	}
+	h.box.Objects = blocks
+	h.box.Refresh()
+}
+
+// heatColor shades a block by the size of the move, reaching full strength at 2%
+func heatColor(change float64) color.Color {
+	base := theme.SuccessColor()
+	if change < 0 {
+		base = theme.ErrorColor()
+	}
+	r, g, b, _ := base.RGBA()
+	strength := math.Min(math.Abs(change)/2, 1)
+	return color.NRGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: uint8(40 + strength*215)}
+}
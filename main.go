@@ -1,55 +1,68 @@
 package main
 
 import (
-	"bytes"
-	_ "embed"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"image/color"
 	"io/ioutil"
 	"log"
 	"math"
 	"net/http"
-	"os"
-	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
-	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
 	"fyne.io/fyne/v2/widget"
+	"gomarket/forecast"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
 	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
 )
 
 // Tiingo API Configuration
-const apiKey = "YOUR_API_KEY" // Replace with your actual Tiingo API key
-const apiURL = "https://api.tiingo.com/tiingo/daily/%s/prices?startDate=%s&token=" + apiKey
+const apiKey = "YOUR_API_KEY" // Replace with your actual Tiingo API key, or set api_key in the config file
+const apiURL = "https://api.tiingo.com/tiingo/daily/%s/prices?startDate=%s&token=%s"
 
 // StockData holds API response data
 type StockData struct {
 	Symbol string  `json:"ticker"`
+	Open   float64 `json:"open"`
+	High   float64 `json:"high"`
+	Low    float64 `json:"low"`
 	Close  float64 `json:"close"`
+	Volume float64 `json:"volume"`
 	Date   string  `json:"date"`
 }
 
-// Embed the ARIMA executable from the assets folder
-//
-//go:embed assets/arima_predict.exe
-var arimaPredictExe []byte
+// forecastHorizons are the forecast lengths offered in the UI, in trading days
+var forecastHorizons = []int{5, 10, 30, 90}
 
 // Define the fetch button before main
 var fetchButton *widget.Button
 
-// fetchStockData retrieves stock data for a given symbol from Tiingo API
+// maxHistoryStart is the start date asked for when fetching all history
+const maxHistoryStart = "1900-01-01"
+
+// fetchStockData retrieves stock data for a given symbol from Tiingo API,
+// going back months or, when months is 0, as far as Tiingo has data
 func fetchStockData(symbol string, months int) ([]StockData, error) {
-	startDate := time.Now().AddDate(0, -months, 0).Format("2006-01-02")
-	url := fmt.Sprintf(apiURL, symbol, startDate)
+	startDate := maxHistoryStart
+	if months > 0 {
+		startDate = time.Now().AddDate(0, -months, 0).Format("2006-01-02")
+	}
+	url := fmt.Sprintf(apiURL, symbol, startDate, settings.Get().APIKey)
+	usage.Record()
 	resp, err := http.Get(url)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", errOffline, err)
 	}
 	defer resp.Body.Close()
 
@@ -57,151 +70,784 @@ func fetchStockData(symbol string, months int) ([]StockData, error) {
 	if err != nil {
 		return nil, err
 	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp.StatusCode, body)
+	}
 
 	var stockData []StockData
 	if err := json.Unmarshal(body, &stockData); err != nil {
-		return nil, err
+		return nil, responseError(resp.StatusCode, body)
 	}
 
 	return stockData, nil
 }
 
-// callPythonARIMA calls the embedded ARIMA executable and returns predictions
-func callPythonARIMA(prices []float64) ([]float64, error) {
-	data := map[string]interface{}{
-		"prices": prices,
+// forecastModels lists the models that can be picked in the UI
+var forecastModels = []string{"ARIMA", "Auto ARIMA", "Holt-Winters", "Trend + Seasonality", "Monte Carlo (GBM)", "Ensemble (mean)", "Ensemble (weighted)", "Naive", "Drift", "Linear trend", "Remote server"}
+
+// newForecaster creates the model with the given name, falling back to ARIMA
+func newForecaster(name string) forecast.Forecaster {
+	switch name {
+	case "Auto ARIMA":
+		return forecast.NewAutoARIMA()
+	case "Holt-Winters":
+		return forecast.NewHoltWinters(5) // one trading week
+	case "Trend + Seasonality":
+		return forecast.NewTrendSeasonal()
+	case "Monte Carlo (GBM)":
+		return forecast.NewGBM(1000)
+	case "Ensemble (mean)", "Ensemble (weighted)":
+		return forecast.NewEnsemble(name == "Ensemble (weighted)",
+			newARIMA(settings.Get().ARIMA), forecast.NewHoltWinters(5), forecast.NewDrift())
+	case "Naive":
+		return forecast.NewNaive()
+	case "Drift":
+		return forecast.NewDrift()
+	case "Linear trend":
+		return forecast.NewLinearTrend()
+	case "Remote server":
+		return forecast.NewRemote(settings.Get().RemoteModelURL)
+	default:
+		return newARIMA(settings.Get().ARIMA)
 	}
-	jsonData, err := json.Marshal(data)
-	if err != nil {
-		return nil, err
+}
+
+// winsorizeTail is the share of the largest moves on each side clipped by winsorization
+const winsorizeTail = 0.01
+
+// preprocess wraps model with the enabled preprocessing steps
+func preprocess(model forecast.Forecaster, p Preprocessing) forecast.Forecaster {
+	if !p.Log && !p.Difference && !p.Winsorize {
+		return model
+	}
+	tail := 0.0
+	if p.Winsorize {
+		tail = winsorizeTail
 	}
+	return forecast.NewPreprocessed(model, p.Log, p.Difference, tail)
+}
 
-	// Create a temporary executable file
-	tempExe, err := ioutil.TempFile("", "arima_predict_*.exe")
+// newARIMA creates an ARIMA model with the given order
+func newARIMA(o ARIMAOrder) *forecast.ARIMA {
+	return forecast.NewSeasonalARIMA(o.P, o.D, o.Q, o.SeasonalP, o.SeasonalD, o.SeasonalQ, o.Period)
+}
+
+// predictPrices fits the selected model to the prices and forecasts the coming
+// days, reusing the cached forecast when the symbol's data hasn't changed
+func predictPrices(symbol string, prices []float64) ([]float64, forecast.Forecaster, error) {
+	s := settings.Get()
+	key := forecastKey{
+		Symbol: symbol,
+		Data:   hashPrices(prices),
+		Model:  s.Model,
+		Params: fmt.Sprintf("%d days %+v %+v %s", s.ForecastDays, s.ARIMA, s.Preprocessing, s.RemoteModelURL),
+	}
+	if r, ok := forecasts.Get(key); ok {
+		log.Printf("Using cached %s forecast for %s\n", r.Model, symbol)
+		return r.Predictions, r.Model, nil
+	}
+
+	model := preprocess(newForecaster(s.Model), s.Preprocessing)
+	if err := model.Fit(prices); err != nil {
+		return nil, model, err
+	}
+	predictions, err := model.Forecast(s.ForecastDays)
 	if err != nil {
-		return nil, err
+		return nil, model, err
 	}
-	defer os.Remove(tempExe.Name()) // Clean up after execution
+	forecasts.Put(key, forecastResult{Predictions: predictions, Model: model})
+	return predictions, model, nil
+}
 
-	// Write the embedded executable to the temporary file
-	if _, err := tempExe.Write(arimaPredictExe); err != nil {
-		return nil, err
+// volatilityBand fits GARCH(1,1) to the prices and returns the expected one sigma
+// move around the predictions along with the next day's volatility
+func volatilityBand(prices, predictions []float64) (priceBand, float64, error) {
+	model := forecast.NewGARCH()
+	if err := model.Fit(prices); err != nil {
+		return priceBand{}, 0, err
+	}
+	vols, err := model.ForecastVolatility(len(predictions))
+	if err != nil || len(vols) == 0 {
+		return priceBand{}, 0, err
 	}
-	tempExe.Close() // Close the file so it can be executed
 
-	// Run the temporary executable
-	cmd := exec.Command(tempExe.Name())
-	cmd.Stdin = bytes.NewReader(jsonData)
+	band := priceBand{
+		Label: "GARCH 1σ",
+		Color: color.NRGBA{G: 128, B: 255, A: 50},
+		Lower: make([]float64, len(predictions)),
+		Upper: make([]float64, len(predictions)),
+	}
+	variance := 0.0
+	for i, p := range predictions {
+		variance += vols[i] * vols[i]
+		move := math.Sqrt(variance)
+		band.Lower[i] = p * math.Exp(-move)
+		band.Upper[i] = p * math.Exp(move)
+	}
+	return band, vols[0], nil
+}
 
-	var out bytes.Buffer
-	var stderr bytes.Buffer
-	cmd.Stdout = &out
-	cmd.Stderr = &stderr
-	err = cmd.Run()
-	if err != nil {
-		log.Println("Error calling ARIMA prediction:", err, "Stderr:", stderr.String())
-		return nil, err
+// intervalBands returns the model's 95% and 80% prediction intervals, which
+// for the Monte Carlo model form its fan of simulated paths
+func intervalBands(model forecast.Forecaster, horizon int) ([]priceBand, error) {
+	var bands []priceBand
+	for _, b := range []struct {
+		level float64
+		alpha uint8
+	}{{0.95, 40}, {0.80, 80}} {
+		lower, upper, err := model.Interval(horizon, b.level)
+		if err != nil {
+			return nil, err
+		}
+		bands = append(bands, priceBand{
+			Label: fmt.Sprintf("%.0f%% interval", b.level*100),
+			Lower: lower,
+			Upper: upper,
+			Color: color.NRGBA{G: 160, A: b.alpha},
+		})
 	}
+	return bands, nil
+}
 
-	var predictions []float64
-	err = json.Unmarshal(out.Bytes(), &predictions)
+// ensembleMembers returns the individual forecasts behind an ensemble model
+func ensembleMembers(model forecast.Forecaster, horizon int) ([]forecastLine, error) {
+	ensemble, ok := model.(*forecast.Ensemble)
+	if !ok {
+		return nil, nil
+	}
+	forecasts, err := ensemble.MemberForecasts(horizon)
 	if err != nil {
 		return nil, err
 	}
+	var lines []forecastLine
+	for i, f := range forecasts {
+		if f != nil {
+			lines = append(lines, forecastLine{Label: ensemble.Members[i].String(), Values: f})
+		}
+	}
+	return lines, nil
+}
+
+// baselineForecasts fits the naive, drift and linear trend models so the
+// selected model can be compared against them
+func baselineForecasts(prices []float64, horizon int) []forecastLine {
+	var lines []forecastLine
+	for _, model := range []forecast.Forecaster{forecast.NewNaive(), forecast.NewDrift(), forecast.NewLinearTrend()} {
+		if err := model.Fit(prices); err != nil {
+			log.Println("Error fitting baseline:", err)
+			continue
+		}
+		f, err := model.Forecast(horizon)
+		if err != nil {
+			log.Println("Error forecasting baseline:", err)
+			continue
+		}
+		lines = append(lines, forecastLine{Label: model.String(), Values: f})
+	}
+	return lines
+}
 
-	return predictions, nil
+// priceBand is a shaded range drawn around the predictions
+type priceBand struct {
+	Label        string
+	Lower, Upper []float64
+	Color        color.NRGBA // translucent, so not premultiplied
 }
 
-// plotData creates and saves a graph with stock data and prediction
-func plotData(prices []float64, predictions []float64, symbol string) error {
-	p := plot.New()
-	p.Title.Text = "Stock Prices and Predictions for " + symbol
-	p.X.Label.Text = "Days"
-	p.Y.Label.Text = "Price"
+// forecastLine is a named forecast drawn next to the main prediction
+type forecastLine struct {
+	Label  string
+	Values []float64
+}
 
-	startIndex := len(prices) - int(math.Min(90, float64(len(prices))))
+// chartData is everything the chart draws for a symbol
+type chartData struct {
+	Symbol      string
+	Prices      []float64
+	Dates       []string       // dates matching Prices, for the axis labels and hover readout
+	BarDays     int            // trading days per bar, for projecting the forecast dates
+	WindowDays  int            // trading days shown before zooming, 0 for all
+	LogScale    bool           // draw prices on a logarithmic axis
+	Percent     bool           // label the price axis with the return since the first visible bar
+	Drawdown    bool           // add a panel of the drawdown from the running peak
+	Predictions []float64      // optional so the history can be shown before the forecast is ready
+	Bands       []priceBand    // shaded ranges around the predictions
+	Members     []forecastLine // ensemble members and baselines drawn as dashed lines
+	Anomalies   []int          // bars of unusual moves, marked on the price line
+	Candles     []StockData    // OHLC bars matching Prices, drawn as candlesticks instead of the close line
+	Volume      []float64      // daily volume matching Prices, drawn in a panel below when set
+	Averages    []averageLine  // moving averages of Prices
+	Bollinger   *bollingerBands
+	Annotations []Annotation // the user's notes and levels, placed using Dates
+
+	BenchmarkSymbol string
+	Benchmark       []float64 // benchmark closes matching Prices, on any scale
+}
 
-	stockPoints := make(plotter.XYs, len(prices)-startIndex)
-	for i := startIndex; i < len(prices); i++ {
-		stockPoints[i-startIndex].X = float64(i - startIndex)
-		stockPoints[i-startIndex].Y = prices[i]
+// chartPlots builds the price plot for c, showing bars start to end counted from
+// the first price, and the panels to draw below it such as volume
+func chartPlots(c chartData, start, end float64) (price *plot.Plot, panels []*plot.Plot, err error) {
+	prices, predictions := c.Prices, c.Predictions
+
+	p := newPlot()
+	p.Title.Text = fmt.Sprintf(lang.L("Stock Prices and Predictions for %s"), c.Symbol)
+	p.X.Label.Text = lang.L("Days")
+	p.Y.Label.Text = lang.L("Price")
+	p.Y.Tick.Marker = numberTicks{}
+	if len(c.Dates) == len(prices) {
+		p.X.Label.Text = lang.L("Date")
+		p.X.Tick.Marker = dateTicks{dates: c.Dates, barDays: c.BarDays}
 	}
 
-	predPoints := make(plotter.XYs, len(predictions))
-	for i := range predictions {
-		predPoints[i].X = float64(len(prices) - startIndex + i)
-		predPoints[i].Y = predictions[i]
+	priceStyle, predictionStyle := seriesLines()
+
+	stockPoints := make(plotter.XYs, len(prices))
+	for i := range prices {
+		stockPoints[i].X = float64(i)
+		stockPoints[i].Y = prices[i]
 	}
 
-	line, _ := plotter.NewLine(stockPoints)
-	line.Color = color.RGBA{R: 255, G: 0, B: 0, A: 255}
+	if len(c.Candles) == len(prices) && hasOHLC(c.Candles) {
+		candles := candlesticks{bars: c.Candles}
+		p.Add(candles)
+		p.Legend.Add(lang.L("Stock"), candles)
+	} else {
+		line, _ := plotter.NewLine(stockPoints)
+		line.LineStyle = priceStyle
 
-	predLine, _ := plotter.NewLine(predPoints)
-	predLine.Color = color.RGBA{G: 255, A: 255}
+		p.Add(line)
+		p.Legend.Add(lang.L("Stock"), line)
+	}
 
-	p.Add(line, predLine)
-	p.Legend.Add("Stock", line)
-	p.Legend.Add("Prediction", predLine)
+	if err := addBenchmark(p, c, start); err != nil {
+		return nil, nil, err
+	}
+
+	if b := c.Bollinger; b != nil {
+		poly := make(plotter.XYs, 0, 2*len(b.Lower))
+		for i, v := range b.Lower {
+			poly = append(poly, plotter.XY{X: float64(b.Start + i), Y: v})
+		}
+		for i := len(b.Upper) - 1; i >= 0; i-- {
+			poly = append(poly, plotter.XY{X: float64(b.Start + i), Y: b.Upper[i]})
+		}
+		shade, err := plotter.NewPolygon(poly)
+		if err != nil {
+			return nil, nil, err
+		}
+		shade.Color = bollingerColor
+		shade.LineStyle.Color = color.NRGBA{R: bollingerColor.R, G: bollingerColor.G, B: bollingerColor.B, A: 160}
+		shade.LineStyle.Width = vg.Points(0.5)
+
+		middle := make(plotter.XYs, len(b.Middle))
+		for i, v := range b.Middle {
+			middle[i] = plotter.XY{X: float64(b.Start + i), Y: v}
+		}
+		middleLine, err := plotter.NewLine(middle)
+		if err != nil {
+			return nil, nil, err
+		}
+		middleLine.LineStyle = shade.LineStyle
+		middleLine.Dashes = []vg.Length{vg.Points(2), vg.Points(2)}
+		p.Add(shade, middleLine)
+		p.Legend.Add(b.Label, shade)
+	}
+
+	for _, avg := range c.Averages {
+		points := make(plotter.XYs, len(avg.Values))
+		for i, v := range avg.Values {
+			points[i].X = float64(avg.Start + i)
+			points[i].Y = v
+		}
+		avgLine, err := plotter.NewLine(points)
+		if err != nil {
+			return nil, nil, err
+		}
+		avgLine.Color = averageColors[avg.Period]
+		if avg.Kind == averageEMA {
+			avgLine.Dashes = []vg.Length{vg.Points(5), vg.Points(2)}
+		}
+		p.Add(avgLine)
+		p.Legend.Add(avg.Label, avgLine)
+	}
+
+	if err := addAnnotations(p, c); err != nil {
+		return nil, nil, err
+	}
+
+	var marks plotter.XYs
+	for _, i := range c.Anomalies {
+		if float64(i) >= start && float64(i) <= end && i < len(prices) {
+			marks = append(marks, plotter.XY{X: float64(i), Y: prices[i]})
+		}
+	}
+	if len(marks) > 0 {
+		scatter, err := plotter.NewScatter(marks)
+		if err != nil {
+			return nil, nil, err
+		}
+		scatter.GlyphStyle.Color = color.RGBA{R: 255, G: 140, A: 255}
+		scatter.GlyphStyle.Shape = draw.RingGlyph{}
+		scatter.GlyphStyle.Radius = vg.Points(4)
+		p.Add(scatter)
+		p.Legend.Add(lang.L("Anomaly"), scatter)
+	}
+
+	for _, band := range c.Bands {
+		poly := make(plotter.XYs, 0, 2*len(band.Lower))
+		for i := range band.Lower {
+			poly = append(poly, plotter.XY{X: float64(len(prices) + i), Y: band.Lower[i]})
+		}
+		for i := len(band.Upper) - 1; i >= 0; i-- {
+			poly = append(poly, plotter.XY{X: float64(len(prices) + i), Y: band.Upper[i]})
+		}
+		shade, err := plotter.NewPolygon(poly)
+		if err != nil {
+			return nil, nil, err
+		}
+		shade.Color = band.Color
+		shade.LineStyle.Width = 0
+		p.Add(shade)
+		p.Legend.Add(band.Label, shade)
+	}
+
+	for i, member := range c.Members {
+		points := make(plotter.XYs, len(member.Values))
+		for j, v := range member.Values {
+			points[j].X = float64(len(prices) + j)
+			points[j].Y = v
+		}
+		memberLine, err := plotter.NewLine(points)
+		if err != nil {
+			return nil, nil, err
+		}
+		memberLine.Color = plotutil.Color(i + 2)
+		memberLine.Dashes = []vg.Length{vg.Points(3), vg.Points(3)}
+		p.Add(memberLine)
+		p.Legend.Add(member.Label, memberLine)
+	}
+
+	if len(predictions) > 0 {
+		predPoints := make(plotter.XYs, len(predictions))
+		for i := range predictions {
+			predPoints[i].X = float64(len(prices) + i)
+			predPoints[i].Y = predictions[i]
+		}
+
+		predLine, _ := plotter.NewLine(predPoints)
+		predLine.LineStyle = predictionStyle
+
+		p.Add(predLine)
+		p.Legend.Add(lang.L("Prediction"), predLine)
+	}
+
+	// Fit the Y axis to what is visible rather than the whole history
+	p.X.Min, p.X.Max = start, end
+	if lo, hi, ok := visibleRange(c, start, end); ok {
+		p.Y.Min, p.Y.Max = lo, hi
+		if c.LogScale && lo > 0 {
+			p.Y.Scale = logScale{}
+		}
+	}
+	if base, ok := returnBase(c, start); c.Percent && ok {
+		p.Y.Label.Text = lang.L("Return %")
+		p.Y.Tick.Marker = returnTicks{base: base}
+	}
 
-	return p.Save(8*vg.Inch, 4*vg.Inch, "plot.png")
+	if len(c.Volume) == len(prices) && hasVolume(c.Volume) {
+		panels = append(panels, volumePlot(volumeBars{volume: c.Volume, closes: prices}, p))
+	}
+	if c.Drawdown {
+		dp, err := drawdownPlot(prices, p)
+		if err != nil {
+			return nil, nil, err
+		}
+		panels = append(panels, dp)
+	}
+
+	// Only the bottom panel needs the axis title
+	if len(panels) > 0 {
+		panels[len(panels)-1].X.Label.Text, p.X.Label.Text = p.X.Label.Text, ""
+	}
+	return p, panels, nil
+}
+
+// logScale is plot.LogScale, except that values at or below zero, such as the
+// lower end of a wide prediction interval, are drawn off the bottom of the axis
+// instead of panicking
+type logScale struct{}
+
+// Normalize implements plot.Normalizer
+func (logScale) Normalize(min, max, x float64) float64 {
+	return plot.LogScale{}.Normalize(min, max, math.Max(x, min/1000))
+}
+
+// visibleRange returns the lowest and highest values charted between bars start and end
+func visibleRange(c chartData, start, end float64) (lo, hi float64, ok bool) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	add := func(i int, values ...float64) {
+		if float64(i) < start || float64(i) > end {
+			return
+		}
+		for _, v := range values {
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+	}
+
+	candles := len(c.Candles) == len(c.Prices) && hasOHLC(c.Candles)
+	for i, v := range c.Prices {
+		if candles {
+			add(i, c.Candles[i].Low, c.Candles[i].High)
+		} else {
+			add(i, v)
+		}
+	}
+	for _, avg := range c.Averages {
+		for i, v := range avg.Values {
+			add(avg.Start+i, v)
+		}
+	}
+	if b := c.Bollinger; b != nil {
+		for i := range b.Lower {
+			add(b.Start+i, b.Lower[i], b.Upper[i])
+		}
+	}
+	n := len(c.Prices)
+	for i, v := range c.Predictions {
+		add(n+i, v)
+	}
+	for _, band := range c.Bands {
+		for i := range band.Lower {
+			add(n+i, band.Lower[i], band.Upper[i])
+		}
+	}
+	for _, member := range c.Members {
+		for i, v := range member.Values {
+			add(n+i, v)
+		}
+	}
+	for i, v := range scaledBenchmark(c, start) {
+		add(i, v)
+	}
+	return lo, hi, lo <= hi
+}
+
+// defaultRange returns the bars shown before the user zooms: the prices in
+// the chosen window and the forecast
+func defaultRange(c chartData) (start, end float64) {
+	shown := len(c.Prices)
+	if c.WindowDays > 0 {
+		shown = min(shown, (c.WindowDays+max(c.BarDays, 1)-1)/max(c.BarDays, 1))
+	}
+	start = float64(len(c.Prices) - shown)
+	end = float64(len(c.Prices) + len(c.Predictions) - 1)
+	return start - 0.5, end + 0.5
 }
 
 func main() {
+	portableFlag := flag.Bool("portable", false, "keep the config next to the executable instead of the user's config directory")
+	configFlag := flag.String("config", "", "config file to use, e.g. work.json to keep a separate profile")
+	flag.Parse()
+	loadTranslations()
+
+	portable := portableDir(*portableFlag)
+	if portable != "" {
+		log.Println("Running portable from", portable)
+	}
+
+	configPath, title := defaultConfigPath(portable), "Stock Analyzer by LewdLillyVT"
+	if *configFlag != "" {
+		configPath = *configFlag
+		title += " - " + strings.TrimSuffix(filepath.Base(configPath), filepath.Ext(configPath))
+	}
+
+	var err error
+	settings, err = openSettings(configPath)
+	if err != nil {
+		log.Println("Error loading settings, using defaults:", err)
+	}
+
 	myApp := app.New()
-	myWindow := myApp.NewWindow("Stock Analyzer by LewdLillyVT")
-	myWindow.Resize(fyne.NewSize(800, 600))
+	applyTheme(myApp, settings.Get().Theme)
+	myWindow := myApp.NewWindow(title)
 
-	stockEntry := widget.NewEntry()
-	stockEntry.SetPlaceHolder("Enter Stock Symbol (e.g., AAPL)")
+	status := newStatusBar(myWindow)
+	usage.onChange = status.UpdateUsage
+	tabs := newWorkspace(myApp, status)
 
-	img := canvas.NewImageFromFile("plot.png")
-	img.FillMode = canvas.ImageFillOriginal
+	stockEntry := newSymbolEntry(func(symbol string) {
+		if symbol != "" && !fetchButton.Disabled() {
+			tabs.Open(symbol)
+		}
+	})
+	stockEntry.SetPlaceHolder(lang.L("Enter Stock Symbol (e.g., AAPL)"))
 
-	// Initialize fetchButton
-	fetchButton = widget.NewButton("Fetch Data", func() {
-		symbol := stockEntry.Text
-		data, err := fetchStockData(symbol, 12) // Fetch data for the last 12 months
-		if err != nil {
-			log.Println("Error fetching data:", err)
+	lookbacks := make([]string, len(lookbackPeriods))
+	for i, p := range lookbackPeriods {
+		lookbacks[i] = p.Label
+	}
+	if label := lookbackLabel(settings.Get().LookbackMonths); !slices.Contains(lookbacks, label) {
+		lookbacks = append(lookbacks, label)
+	}
+	lookbackSelect := widget.NewSelect(lookbacks, func(label string) {
+		s := settings.Get()
+		if lookbackLabel(s.LookbackMonths) == label {
 			return
 		}
+		for _, p := range lookbackPeriods {
+			if p.Label == label {
+				s.LookbackMonths = p.Months
+			}
+		}
+		if err := settings.Set(s); err != nil {
+			log.Println("Error saving settings:", err)
+		}
+	})
+	lookbackSelect.SetSelected(lookbackLabel(settings.Get().LookbackMonths))
+	autoRefresh := newAutoRefresh(tabs)
 
-		log.Printf("Fetched %d data points for symbol: %s\n", len(data), symbol)
+	// Initialize fetchButton
+	fetchButton = widget.NewButton(lang.L("Fetch Data"), func() {
+		tabs.Open(stockEntry.Text)
+	})
+	watchlist := newWatchlistPanel(tabs.Open)
+	tabs.onBusy = func(busy bool) {
+		if busy {
+			fetchButton.SetText(lang.L("Fetching…"))
+			fetchButton.Disable()
+		} else {
+			fetchButton.SetText(lang.L("Fetch Data"))
+			fetchButton.Enable()
+		}
+	}
 
-		if len(data) == 0 {
-			log.Println("No data returned for symbol:", symbol)
-			return
+	modelSelect := newLocalSelect(forecastModels, func(name string) {
+		s := settings.Get()
+		s.Model = name
+		if err := settings.Set(s); err != nil {
+			log.Println("Error saving settings:", err)
+		}
+	})
+	modelSelect.SetValue(settings.Get().Model)
+
+	horizonLabels := make([]string, len(forecastHorizons))
+	for i, days := range forecastHorizons {
+		horizonLabels[i] = fmt.Sprintf(lang.L("%d days"), days)
+	}
+	horizonSelect := widget.NewSelect(horizonLabels, func(label string) {
+		s := settings.Get()
+		fmt.Sscanf(label, lang.L("%d days"), &s.ForecastDays)
+		if err := settings.Set(s); err != nil {
+			log.Println("Error saving settings:", err)
 		}
+	})
+	horizonSelect.SetSelected(fmt.Sprintf(lang.L("%d days"), settings.Get().ForecastDays))
 
-		prices := make([]float64, len(data))
-		for i, d := range data {
-			prices[i] = d.Close
+	baselineCheck := widget.NewCheck(lang.L("Baselines"), func(on bool) {
+		s := settings.Get()
+		s.Baselines = on
+		if err := settings.Set(s); err != nil {
+			log.Println("Error saving settings:", err)
 		}
+	})
+	baselineCheck.SetChecked(settings.Get().Baselines)
 
-		log.Printf("Prices for %s: %v\n", symbol, prices)
+	volCheck := widget.NewCheck(lang.L("Volatility band"), func(on bool) {
+		s := settings.Get()
+		s.VolatilityBand = on
+		if err := settings.Set(s); err != nil {
+			log.Println("Error saving settings:", err)
+		}
+	})
+	volCheck.SetChecked(settings.Get().VolatilityBand)
 
-		if len(prices) < 2 { // Ensure enough data for predictions
-			log.Println("Not enough data points for predictions.")
-			return
+	logCheck := widget.NewCheck(lang.L("Log scale"), func(on bool) {
+		s := settings.Get()
+		s.LogScale = on
+		if err := settings.Set(s); err != nil {
+			log.Println("Error saving settings:", err)
 		}
+		for _, v := range tabs.Views() {
+			v.plot.SetLogScale(on)
+		}
+	})
+	logCheck.SetChecked(settings.Get().LogScale)
 
-		predictions, err := callPythonARIMA(prices)
-		if err != nil {
-			log.Println("Error calling ARIMA prediction:", err)
-			return
+	percentCheck := widget.NewCheck(lang.L("% return"), func(on bool) {
+		s := settings.Get()
+		s.PercentReturn = on
+		if err := settings.Set(s); err != nil {
+			log.Println("Error saving settings:", err)
+		}
+		for _, v := range tabs.Views() {
+			v.plot.SetPercent(on)
 		}
+	})
+	percentCheck.SetChecked(settings.Get().PercentReturn)
+
+	volumeCheck := widget.NewCheck(lang.L("Volume"), func(on bool) {
+		s := settings.Get()
+		s.ShowVolume = on
+		if err := settings.Set(s); err != nil {
+			log.Println("Error saving settings:", err)
+		}
+	})
+	volumeCheck.SetChecked(settings.Get().ShowVolume)
 
-		if err := plotData(prices, predictions, symbol); err != nil {
-			log.Println("Error plotting data:", err)
+	drawdownCheck := widget.NewCheck(lang.L("Drawdown"), func(on bool) {
+		s := settings.Get()
+		s.ShowDrawdown = on
+		if err := settings.Set(s); err != nil {
+			log.Println("Error saving settings:", err)
+		}
+		for _, v := range tabs.Views() {
+			v.plot.SetDrawdown(on)
+		}
+	})
+	drawdownCheck.SetChecked(settings.Get().ShowDrawdown)
+
+	benchmarkCheck := widget.NewCheck(lang.L("Benchmark"), func(on bool) {
+		s := settings.Get()
+		if s.ShowBenchmark == on {
 			return
 		}
+		s.ShowBenchmark = on
+		if err := settings.Set(s); err != nil {
+			log.Println("Error saving settings:", err)
+		}
+	})
+	benchmarkCheck.SetChecked(settings.Get().ShowBenchmark)
+
+	averages := averagesMenu()
+	history := historyMenu(tabs.Open)
+	themeItem := themeMenu()
+	contrastItem := highContrastItem()
+	viewMenu := fyne.NewMenu(lang.L("View"),
+		fyne.NewMenuItem(lang.L("Market Summary"), func() { go showDailySummary(myWindow) }),
+		fyne.NewMenuItem(lang.L("Volatility Cone"), func() { showVolatilityCone(myApp, tabs.Current()) }),
+		fyne.NewMenuItem(lang.L("Decomposition"), func() { showDecomposition(myApp, tabs.Current()) }),
+		fyne.NewMenuItem(lang.L("Backtest Models"), func() { showBacktest(myApp, tabs.Current()) }),
+		fyne.NewMenuItem(lang.L("Anomalies"), func() { showAnomalies(myApp, tabs.Current()) }),
+		fyne.NewMenuItem(lang.L("Side by Side..."), func() { showSideBySide(myApp, tabs.Current()) }),
+		fyne.NewMenuItem(lang.L("Watchlist Screener"), func() { showScreener(myApp, watchlist) }),
+		fyne.NewMenuItem(lang.L("Pop Out Chart"), tabs.PopOutCurrent),
+		fyne.NewMenuItemSeparator(),
+		themeItem,
+		contrastItem,
+	)
+
+	advanced, setAdvancedFields := newAdvancedPanel(status)
+
+	// Keep the data age labels current
+	go func() {
+		for range time.Tick(30 * time.Second) {
+			tabs.Current().UpdateAge()
+			status.UpdateUsage()
+		}
+	}()
+
+	// Apply changes as they come in, from the controls or from the config file
+	// being edited, redrawing the charts from their loaded bars when the
+	// overlays change and fetching again only for a new lookback
+	shown := settings.Get()
+	settings.OnChange(func(s Settings) {
+		tabs.Current().UpdateAge()
+		status.UpdateUsage()
+		// Selects call back even when set to the value they show, so they
+		// are only set when their setting changed
+		if s.Model != shown.Model {
+			modelSelect.SetValue(s.Model)
+		}
+		volCheck.SetChecked(s.VolatilityBand)
+		baselineCheck.SetChecked(s.Baselines)
+		volumeCheck.SetChecked(s.ShowVolume)
+		benchmarkCheck.SetChecked(s.ShowBenchmark)
+		drawdownCheck.SetChecked(s.ShowDrawdown)
+		logCheck.SetChecked(s.LogScale)
+		percentCheck.SetChecked(s.PercentReturn)
+		if s.ForecastDays != shown.ForecastDays {
+			horizonSelect.SetSelected(fmt.Sprintf(lang.L("%d days"), s.ForecastDays))
+		}
+		if s.LookbackMonths != shown.LookbackMonths {
+			lookbackSelect.SetSelected(lookbackLabel(s.LookbackMonths))
+		}
+		autoRefresh.SetMinutes(s.AutoRefreshMinutes)
+		for _, v := range tabs.Views() {
+			if s.ChartStyle != shown.ChartStyle {
+				v.style.SetValue(s.ChartStyle)
+			}
+			if s.BarDays != shown.BarDays {
+				v.bars.SetSelected(barLabel(s.BarDays))
+			}
+			if s.ChartWindow != shown.ChartWindow {
+				v.window.SetSelected(s.ChartWindow)
+			}
+		}
+		checkAverages(averages, s)
+		if s.HighContrast != shown.HighContrast {
+			contrastItem.Checked = s.HighContrast
+			viewMenu.Refresh()
+		}
+		if s.Theme != shown.Theme {
+			applyTheme(myApp, s.Theme)
+			checkTheme(themeItem, s.Theme)
+			for _, v := range tabs.Views() {
+				v.UpdateTheme()
+			}
+		}
+		if !slices.Equal(s.RecentSymbols, shown.RecentSymbols) {
+			updateHistory(history, s.RecentSymbols, tabs.Open)
+		}
+		watchlist.SetSymbols(s.Watchlist)
+		if s.LookbackMonths != shown.LookbackMonths {
+			tabs.ReloadAll()
+		} else if overlaysChanged(shown, s) || s.ShowVolume != shown.ShowVolume || s.VolatilityBand != shown.VolatilityBand || s.Baselines != shown.Baselines || s.ShowBenchmark != shown.ShowBenchmark || s.Benchmark != shown.Benchmark {
+			tabs.RedrawAll()
+		} else if s.ChartPalette != shown.ChartPalette || s.SeriesStyle != shown.SeriesStyle || s.HighContrast != shown.HighContrast {
+			for _, v := range tabs.Views() {
+				v.plot.Refresh()
+			}
+		}
+		shown = s
+	})
+	// The Advanced fields are only refilled when the settings come from a
+	// file, so edits not yet applied survive other controls saving
+	settings.OnReload(func(s Settings) {
+		status.Set("Settings reloaded from %s", settings.path)
+		setAdvancedFields(s)
+	})
+	if err := settings.Watch(); err != nil {
+		log.Println("Error watching config file:", err)
+	}
 
-		// Update the image
-		img = canvas.NewImageFromFile("plot.png")
-		img.FillMode = canvas.ImageFillOriginal
-		myWindow.SetContent(container.NewVBox(stockEntry, fetchButton, img))
+	myWindow.SetMainMenu(fyne.NewMainMenu(
+		fyne.NewMenu(lang.L("File"),
+			fyne.NewMenuItem(lang.L("Import Settings..."), func() { importSettings(myWindow, status) }),
+			fyne.NewMenuItem(lang.L("Export Settings..."), func() { exportSettings(myWindow, status) }),
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem(lang.L("Export Chart..."), func() { exportChart(myWindow, tabs.Current(), status) }),
+			fyne.NewMenuItem(lang.L("Export Stats..."), func() { exportStats(myWindow, tabs.Current(), status) }),
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem(lang.L("New Tab"), func() { tabs.NewTab() }),
+			fyne.NewMenuItem(lang.L("Close Tab"), tabs.CloseCurrent),
+		),
+		viewMenu,
+		averages,
+		history,
+	))
+
+	sidebar := container.NewHSplit(watchlist.content, tabs.tabs)
+	restoreWindow(myWindow, sidebar, tabs)
+	myWindow.SetOnClosed(func() {
+		saveWindowState(myWindow, sidebar, tabs)
+		tabs.DockAll()
 	})
+	myWindow.SetOnDropped(func(_ fyne.Position, uris []fyne.URI) { openDroppedFiles(uris, tabs, status) })
 
-	myWindow.SetContent(container.NewVBox(stockEntry, fetchButton, img))
+	controls := container.NewBorder(nil, nil, nil, container.NewHBox(logCheck, percentCheck, volumeCheck, drawdownCheck, benchmarkCheck, baselineCheck, volCheck, horizonSelect, modelSelect), fetchButton)
+	myWindow.SetContent(container.NewBorder(container.NewVBox(container.NewBorder(nil, nil, widget.NewLabel(lang.L("Symbol")), container.NewHBox(lookbackSelect, autoRefresh.content), stockEntry), controls, advanced), status.content, nil, nil, sidebar))
+	addShortcuts(myWindow, stockEntry, tabs)
+	setupTray(myApp, myWindow, watchlist, tabs)
+	showDailySummaryOnLaunch(myWindow)
 	myWindow.ShowAndRun()
 }
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2/lang"
+	"gomarket/stats"
+)
+
+// Number of trading days in a year, used for 52-week figures
+const tradingDaysPerYear = stats.TradingDaysPerYear
+
+// percentOffHigh returns how far the last close is below the highest close of the last 52 weeks
+func percentOffHigh(closes []float64) float64 {
+	if len(closes) == 0 {
+		return 0
+	}
+	start := len(closes) - tradingDaysPerYear
+	if start < 0 {
+		start = 0
+	}
+	high := closes[start]
+	for _, c := range closes[start:] {
+		if c > high {
+			high = c
+		}
+	}
+	if high == 0 {
+		return 0
+	}
+	return (high - closes[len(closes)-1]) / high * 100
+}
+
+// streak returns the number of consecutive up closes ending at the last bar,
+// or minus the number of consecutive down closes
+func streak(closes []float64) int {
+	n := 0
+	for i := len(closes) - 1; i > 0; i-- {
+		switch {
+		case closes[i] > closes[i-1] && n >= 0:
+			n++
+		case closes[i] < closes[i-1] && n <= 0:
+			n--
+		default:
+			return n
+		}
+	}
+	return n
+}
+
+// daysSinceDrawdown returns the number of bars since the close was last at least
+// threshold percent below its running peak, or -1 if that never happened
+func daysSinceDrawdown(closes []float64, threshold float64) int {
+	last := -1
+	peak := 0.0
+	for i, c := range closes {
+		if c > peak {
+			peak = c
+		}
+		if peak > 0 && (peak-c)/peak*100 >= threshold {
+			last = i
+		}
+	}
+	if last < 0 {
+		return -1
+	}
+	return len(closes) - 1 - last
+}
+
+// formatMetrics summarizes the high, streak and drawdown figures in one line
+func formatMetrics(closes []float64) string {
+	s := streak(closes)
+	streakText := lang.L("flat")
+	switch {
+	case s > 0:
+		streakText = fmt.Sprintf(lang.L("%d up days"), s)
+	case s < 0:
+		streakText = fmt.Sprintf(lang.L("%d down days"), -s)
+	}
+
+	drawdownText := lang.L("no 5% drawdown in range")
+	if d := daysSinceDrawdown(closes, 5); d >= 0 {
+		drawdownText = fmt.Sprintf(lang.L("%d days since 5%% drawdown"), d)
+	}
+
+	return fmt.Sprintf(lang.L("%.1f%% below 52w high · %s · %s"), percentOffHigh(closes), streakText, drawdownText)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"log"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/widget"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// plotImage shows a static chart, drawing it again whenever it is resized so
+// it fills the space at the screen's pixel density
+type plotImage struct {
+	widget.BaseWidget
+
+	draw     func(draw.Canvas)
+	onError  func(error)
+	image    *canvas.Image
+	rendered fyne.Size
+	minSize  fyne.Size
+}
+
+// newPlotImage creates a chart drawn by fn, usually a plot's Draw method
+func newPlotImage(fn func(draw.Canvas)) *plotImage {
+	p := &plotImage{draw: fn, image: canvas.NewImageFromImage(nil), minSize: fyne.NewSize(400, 250)}
+	p.image.FillMode = canvas.ImageFillStretch
+	p.ExtendBaseWidget(p)
+	return p
+}
+
+// CreateRenderer implements fyne.Widget
+func (p *plotImage) CreateRenderer() fyne.WidgetRenderer {
+	return &plotImageRenderer{plot: p}
+}
+
+// render draws the chart at size
+func (p *plotImage) render(size fyne.Size) {
+	p.rendered = size
+	if size.Width < 1 || size.Height < 1 {
+		return
+	}
+	img, err := renderDrawing(p.draw, vg.Length(size.Width), vg.Length(size.Height), displayDPI(p))
+	if err != nil {
+		log.Println("Error plotting data:", err)
+		if p.onError != nil {
+			p.onError(err)
+		}
+	}
+	p.image.Image = img
+	p.image.Refresh()
+}
+
+// displayDPI returns the resolution that gives one image pixel per screen
+// pixel when one point is drawn per Fyne unit
+func displayDPI(o fyne.CanvasObject) float64 {
+	scale := float32(1)
+	if app := fyne.CurrentApp(); app != nil {
+		if c := app.Driver().CanvasForObject(o); c != nil {
+			scale = c.Scale()
+		}
+	}
+	return 72 * float64(scale)
+}
+
+// renderDrawing runs fn on an in-memory image of the given size, turning a
+// panic in the plotting code into an error
+func renderDrawing(fn func(draw.Canvas), width, height vg.Length, dpi float64) (img image.Image, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("rendering chart: %v", r)
+		}
+	}()
+	c := newChartCanvas(width, height, dpi)
+	fn(draw.New(c))
+	return c.Image(), nil
+}
+
+// plotImageRenderer lays out the chart image
+type plotImageRenderer struct {
+	plot *plotImage
+}
+
+func (r *plotImageRenderer) Layout(size fyne.Size) {
+	r.plot.image.Resize(size)
+	if size != r.plot.rendered {
+		r.plot.render(size)
+	}
+}
+
+func (r *plotImageRenderer) MinSize() fyne.Size {
+	return r.plot.minSize
+}
+
+func (r *plotImageRenderer) Refresh() {
+	r.plot.render(r.plot.Size())
+}
+
+func (r *plotImageRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.plot.image}
+}
+
+func (r *plotImageRenderer) Destroy() {}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"math"
+	"strings"
+
+	"fyne.io/fyne/v2/theme"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+// Legend corners offered for the charts
+const (
+	legendTopLeft     = "top left"
+	legendTopRight    = "top right"
+	legendBottomLeft  = "bottom left"
+	legendBottomRight = "bottom right"
+)
+
+var legendPositions = []string{legendTopLeft, legendTopRight, legendBottomLeft, legendBottomRight}
+
+// chartPalette returns the chart background and text colors, those set in
+// the settings or else the ones of the current Fyne theme so charts match it
+func chartPalette() (bg, fg color.Color) {
+	if settings.Get().HighContrast {
+		return color.Black, color.White
+	}
+	p := settings.Get().ChartPalette
+	return settingColor(p.Background, theme.BackgroundColor(), "chart background"),
+		settingColor(p.Foreground, theme.ForegroundColor(), "chart foreground")
+}
+
+// highContrastWidth is the thinnest line drawn in high contrast mode
+const highContrastWidth = 2.5
+
+// seriesLines returns the line styles of the price and the prediction
+func seriesLines() (price, prediction draw.LineStyle) {
+	if settings.Get().HighContrast {
+		width := vg.Points(max(settings.Get().SeriesStyle.LineWidth, highContrastWidth))
+		price = draw.LineStyle{Color: color.RGBA{R: 255, G: 255, A: 255}, Width: width}
+		prediction = draw.LineStyle{Color: color.RGBA{G: 255, B: 255, A: 255}, Width: width, Dashes: []vg.Length{vg.Points(8), vg.Points(4)}}
+		return price, prediction
+	}
+	s := settings.Get().SeriesStyle
+	width := vg.Points(1)
+	if s.LineWidth > 0 {
+		width = vg.Points(s.LineWidth)
+	}
+	price = draw.LineStyle{Color: settingColor(s.PriceColor, color.RGBA{R: 255, A: 255}, "price"), Width: width}
+	prediction = draw.LineStyle{Color: settingColor(s.PredictionColor, color.RGBA{G: 255, A: 255}, "prediction"), Width: width}
+	if s.DashedPrediction {
+		prediction.Dashes = []vg.Length{vg.Points(6), vg.Points(3)}
+	}
+	return price, prediction
+}
+
+// settingColor parses a color from the settings, using def when it is empty
+// or can't be read
+func settingColor(s string, def color.Color, name string) color.Color {
+	if s == "" {
+		return def
+	}
+	c, err := parseHexColor(s)
+	if err != nil {
+		log.Printf("Error reading %s color: %v\n", name, err)
+		return def
+	}
+	return c
+}
+
+// parseHexColor parses a "#rrggbb" or "#rrggbbaa" color
+func parseHexColor(s string) (color.NRGBA, error) {
+	c := color.NRGBA{A: 255}
+	var err error
+	switch len(s) {
+	case 7:
+		_, err = fmt.Sscanf(s, "#%02x%02x%02x", &c.R, &c.G, &c.B)
+	case 9:
+		_, err = fmt.Sscanf(s, "#%02x%02x%02x%02x", &c.R, &c.G, &c.B, &c.A)
+	default:
+		err = fmt.Errorf("%q is not a #rrggbb color", s)
+	}
+	return c, err
+}
+
+// newPlot creates a plot colored with the chart palette
+func newPlot() *plot.Plot {
+	p := plot.New()
+	bg, fg := chartPalette()
+	p.BackgroundColor = bg
+	p.Title.TextStyle.Color = fg
+	p.Legend.TextStyle.Color = fg
+	legend := settings.Get().SeriesStyle.Legend
+	p.Legend.Top = strings.HasPrefix(legend, "top")
+	p.Legend.Left = strings.HasSuffix(legend, "left")
+	for _, a := range []*plot.Axis{&p.X, &p.Y} {
+		a.Color = fg
+		a.Label.TextStyle.Color = fg
+		a.Tick.Color = fg
+		a.Tick.Label.Color = fg
+	}
+	return p
+}
+
+// newChartCanvas creates an image canvas at dpi filled with the chart
+// background, so gaps between plots drawn side by side match them
+func newChartCanvas(width, height vg.Length, dpi float64) *vgimg.Canvas {
+	bg, _ := chartPalette()
+	return vgimg.NewWith(vgimg.UseWH(width, height), vgimg.UseDPI(int(math.Round(dpi))), vgimg.UseBackgroundColor(bg))
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"log"
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/lang"
+)
+
+// maxRecentSymbols is how many fetched symbols the History menu remembers
+const maxRecentSymbols = 10
+
+// rememberSymbol moves symbol to the top of the recently fetched symbols
+func rememberSymbol(symbol string) {
+	s := settings.Get()
+	recent := slices.DeleteFunc(slices.Clone(s.RecentSymbols), func(r string) bool { return r == symbol })
+	recent = append([]string{symbol}, recent[:min(len(recent), maxRecentSymbols-1)]...)
+	if slices.Equal(recent, s.RecentSymbols) {
+		return
+	}
+	s.RecentSymbols = recent
+	if err := settings.Set(s); err != nil {
+		log.Println("Error saving settings:", err)
+	}
+}
+
+// historyMenu creates the menu of recently fetched symbols, calling open
+// with the one picked
+func historyMenu(open func(string)) *fyne.Menu {
+	menu := fyne.NewMenu(lang.L("History"))
+	updateHistory(menu, settings.Get().RecentSymbols, open)
+	return menu
+}
+
+// updateHistory lists recent in a history menu
+func updateHistory(menu *fyne.Menu, recent []string, open func(string)) {
+	menu.Items = nil
+	for _, symbol := range recent {
+		menu.Items = append(menu.Items, fyne.NewMenuItem(symbol, func() { open(symbol) }))
+	}
+	if len(recent) == 0 {
+		empty := fyne.NewMenuItem(lang.L("No recent symbols"), nil)
+		empty.Disabled = true
+		menu.Items = append(menu.Items, empty)
+	}
+
+	clearItem := fyne.NewMenuItem(lang.L("Clear History"), func() {
+		s := settings.Get()
+		s.RecentSymbols = nil
+		if err := settings.Set(s); err != nil {
+			log.Println("Error saving settings:", err)
+		}
+	})
+	clearItem.Disabled = len(recent) == 0
+	menu.Items = append(menu.Items, fyne.NewMenuItemSeparator(), clearItem)
+	menu.Refresh()
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2/lang"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// Chart modes offered for a symbol
+const (
+	modePrice          = "Price"
+	modeBetaAdjusted   = "Beta-adjusted"
+	modeSectorRelative = "Sector-relative"
+)
+
+var chartModes = []string{modePrice, modeBetaAdjusted, modeSectorRelative, modeCompare}
+
+// benchmarkSymbol is the market proxy used for beta
+const benchmarkSymbol = "SPY"
+
+// sectorETFs maps well known symbols to their SPDR sector fund
+var sectorETFs = map[string]string{
+	"AAPL": "XLK", "MSFT": "XLK", "NVDA": "XLK", "AVGO": "XLK", "ORCL": "XLK", "ADBE": "XLK", "CRM": "XLK", "AMD": "XLK", "INTC": "XLK",
+	"GOOGL": "XLC", "GOOG": "XLC", "META": "XLC", "NFLX": "XLC", "DIS": "XLC", "T": "XLC", "VZ": "XLC",
+	"AMZN": "XLY", "TSLA": "XLY", "HD": "XLY", "MCD": "XLY", "NKE": "XLY", "SBUX": "XLY",
+	"JPM": "XLF", "BAC": "XLF", "WFC": "XLF", "GS": "XLF", "MS": "XLF", "V": "XLF", "MA": "XLF", "BRK-B": "XLF",
+	"XOM": "XLE", "CVX": "XLE", "COP": "XLE", "SLB": "XLE",
+	"JNJ": "XLV", "UNH": "XLV", "PFE": "XLV", "LLY": "XLV", "MRK": "XLV", "ABBV": "XLV",
+	"PG": "XLP", "KO": "XLP", "PEP": "XLP", "WMT": "XLP", "COST": "XLP",
+	"BA": "XLI", "CAT": "XLI", "GE": "XLI", "HON": "XLI", "UPS": "XLI",
+	"NEE": "XLU", "DUK": "XLU", "SO": "XLU",
+	"LIN": "XLB", "DOW": "XLB",
+	"AMT": "XLRE", "PLD": "XLRE",
+}
+
+// sectorETF returns the sector fund for symbol, or an empty string if unknown
+func sectorETF(symbol string) string {
+	return sectorETFs[symbol]
+}
+
+// alignReturns returns the daily returns of a and b over the dates both series have
+func alignReturns(a, b []StockData) (ra, rb []float64) {
+	closes := make(map[string]float64, len(b))
+	for _, d := range b {
+		closes[d.Date] = d.Close
+	}
+
+	var prevA, prevB float64
+	for _, d := range a {
+		cb, ok := closes[d.Date]
+		if !ok {
+			continue
+		}
+		if prevA != 0 && prevB != 0 {
+			ra = append(ra, d.Close/prevA-1)
+			rb = append(rb, cb/prevB-1)
+		}
+		prevA, prevB = d.Close, cb
+	}
+	return ra, rb
+}
+
+// beta returns the sensitivity of ra to rb, cov(ra, rb) / var(rb)
+func beta(ra, rb []float64) float64 {
+	if len(ra) < 2 {
+		return 0
+	}
+	var ma, mb float64
+	for i := range ra {
+		ma += ra[i]
+		mb += rb[i]
+	}
+	ma /= float64(len(ra))
+	mb /= float64(len(rb))
+
+	var cov, variance float64
+	for i := range ra {
+		cov += (ra[i] - ma) * (rb[i] - mb)
+		variance += (rb[i] - mb) * (rb[i] - mb)
+	}
+	if variance == 0 {
+		return 0
+	}
+	return cov / variance
+}
+
+// cumulativeExcess returns the running percent return of ra in excess of k times rb
+func cumulativeExcess(ra, rb []float64, k float64) []float64 {
+	out := make([]float64, len(ra))
+	growth := 1.0
+	for i := range ra {
+		growth *= 1 + ra[i] - k*rb[i]
+		out[i] = (growth - 1) * 100
+	}
+	return out
+}
+
+// plotRelative builds a chart of a cumulative excess return series
+func plotRelative(values []float64, title string) (*plot.Plot, error) {
+	p := newPlot()
+	p.Title.Text = title
+	p.X.Label.Text = lang.L("Days")
+	p.Y.Label.Text = lang.L("Excess return (%)")
+
+	points := make(plotter.XYs, len(values))
+	for i, v := range values {
+		points[i].X = float64(i)
+		points[i].Y = v
+	}
+
+	line, err := plotter.NewLine(points)
+	if err != nil {
+		return nil, err
+	}
+	line.Color = color.RGBA{R: 255, A: 255}
+	zero := plotter.NewFunction(func(float64) float64 { return 0 })
+	zero.Color = color.RGBA{R: 128, G: 128, B: 128, A: 255}
+	zero.Dashes = []vg.Length{vg.Points(4), vg.Points(2)}
+
+	p.Add(zero, line)
+	p.Legend.Add(lang.L("Excess return"), line)
+
+	return p, nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import "strings"
+
+// symbolRenames maps old tickers to the ones the same company trades under now
+var symbolRenames = map[string]string{
+	"FB":   "META",
+	"PCLN": "BKNG",
+	"ANTM": "ELV",
+	"FISV": "FI",
+	"KORS": "CPRI",
+	"HRS":  "LHX",
+	"WLTW": "WTW",
+	"BLL":  "BALL",
+	"PKI":  "RVTY",
+	"VIAC": "PARA",
+}
+
+// resolveSymbol follows ticker changes, user defined ones first, and reports
+// whether symbol was renamed
+func resolveSymbol(symbol string) (string, bool) {
+	user := settings.Get().SymbolRenames
+	current := strings.ToUpper(strings.TrimSpace(symbol))
+	renamed := false
+	for i := 0; i < 10; i++ { // bounded in case the renames form a loop
+		next, ok := user[current]
+		if !ok {
+			next, ok = symbolRenames[current]
+		}
+		if !ok || next == current {
+			break
+		}
+		current, renamed = next, true
+	}
+	return current, renamed
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"math"
+
+	"gonum.org/v1/plot"
+)
+
+// returnBase returns the price the percent return is measured from: the
+// close at the first bar from start, like the benchmark overlay
+func returnBase(c chartData, start float64) (float64, bool) {
+	if len(c.Prices) == 0 {
+		return 0, false
+	}
+	first := min(max(int(math.Ceil(start)), 0), len(c.Prices)-1)
+	base := c.Prices[first]
+	return base, base > 0
+}
+
+// percentReturn returns the cumulative return of price over base in percent
+func percentReturn(price, base float64) float64 {
+	return (price/base - 1) * 100
+}
+
+// returnTicks labels a price axis with the return over base. The data is
+// left as prices so the forecast, bands and overlays move with the same
+// transformation, only the ticks are placed at round percentages
+type returnTicks struct {
+	base float64
+}
+
+// Ticks implements plot.Ticker
+func (t returnTicks) Ticks(min, max float64) []plot.Tick {
+	ticks := plot.DefaultTicks{}.Ticks(percentReturn(min, t.base), percentReturn(max, t.base))
+	for i, tick := range ticks {
+		ticks[i].Value = t.base * (1 + tick.Value/100)
+		if tick.Label != "" {
+			ticks[i].Label = formatPercent(tick.Value)
+		}
+	}
+	return ticks
+}
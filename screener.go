@@ -0,0 +1,199 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+)
+
+// screenerColumns are the columns of the watchlist screener
+var screenerColumns = []string{"Symbol", "Last", "Day %", "Below 52w high %", "Streak", "Days since 5% drawdown"}
+
+// screenerRow is a watched symbol's figures in the order of screenerColumns
+// after the symbol. Values not loaded yet are NaN
+type screenerRow struct {
+	Symbol string
+	Values [5]float64
+}
+
+// screenerRows lists the symbols with their quote figures
+func screenerRows(symbols []string, quotes map[string]watchQuote) []screenerRow {
+	rows := make([]screenerRow, len(symbols))
+	for i, symbol := range symbols {
+		nan := math.NaN()
+		rows[i] = screenerRow{Symbol: symbol, Values: [5]float64{nan, nan, nan, nan, nan}}
+		q, ok := quotes[symbol]
+		if !ok {
+			continue
+		}
+		rows[i].Values = [5]float64{q.Close, q.Percent, q.OffHigh, float64(q.Streak), float64(q.SinceDrawdown)}
+		if q.SinceDrawdown < 0 {
+			rows[i].Values[4] = nan
+		}
+	}
+	return rows
+}
+
+// screener shows the watchlist as a table of figures, sorted by the column
+// whose header was last clicked
+type screener struct {
+	mu      sync.Mutex // guards the rows and order, set from the quote goroutines
+	rows    []screenerRow
+	sortCol int
+	desc    bool
+	table   *widget.Table
+}
+
+// newScreener creates a table of the watchlist's symbols, opening the chart
+// of a row when it is clicked
+func newScreener(watchlist *watchlistPanel) *screener {
+	s := &screener{}
+	s.table = widget.NewTableWithHeaders(
+		func() (int, int) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			return len(s.rows), len(screenerColumns)
+		},
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, o fyne.CanvasObject) {
+			label := o.(*widget.Label)
+			row, ok := s.row(id.Row)
+			if !ok {
+				label.SetText("")
+				return
+			}
+			label.Alignment = fyne.TextAlignTrailing
+			if id.Col == 0 {
+				label.Alignment = fyne.TextAlignLeading
+				label.SetText(row.Symbol)
+				return
+			}
+			v := row.Values[id.Col-1]
+			switch {
+			case math.IsNaN(v):
+				label.SetText("")
+			case id.Col == 1:
+				label.SetText(formatPrice(v, symbolCurrency(row.Symbol)))
+			case id.Col == 2:
+				label.SetText(formatPercent(v))
+			case id.Col == 3:
+				label.SetText(formatNumber(v, 1))
+			case id.Col == 4:
+				label.SetText(fmt.Sprintf("%+d", int(v)))
+			default:
+				label.SetText(formatNumber(v, 0))
+			}
+		})
+	s.table.ShowHeaderColumn = false
+	s.table.CreateHeader = func() fyne.CanvasObject {
+		return widget.NewButton("", nil)
+	}
+	s.table.UpdateHeader = func(id widget.TableCellID, o fyne.CanvasObject) {
+		button := o.(*widget.Button)
+		if id.Col < 0 {
+			return
+		}
+		text := lang.L(screenerColumns[id.Col])
+		s.mu.Lock()
+		if id.Col == s.sortCol {
+			text += map[bool]string{false: " ▲", true: " ▼"}[s.desc]
+		}
+		s.mu.Unlock()
+		button.SetText(text)
+		button.OnTapped = func() { s.sortBy(id.Col) }
+	}
+	s.table.OnSelected = func(id widget.TableCellID) {
+		if row, ok := s.row(id.Row); ok {
+			watchlist.onOpen(row.Symbol)
+		}
+		s.table.UnselectAll()
+	}
+	s.table.SetColumnWidth(0, 90)
+	for col := 1; col < len(screenerColumns); col++ {
+		s.table.SetColumnWidth(col, 150)
+	}
+	return s
+}
+
+// row returns the row at i, ok is false past the end
+func (s *screener) row(i int) (screenerRow, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i < 0 || i >= len(s.rows) {
+		return screenerRow{}, false
+	}
+	return s.rows[i], true
+}
+
+// SetQuotes shows the figures of symbols
+func (s *screener) SetQuotes(symbols []string, quotes map[string]watchQuote) {
+	s.mu.Lock()
+	s.rows = screenerRows(symbols, quotes)
+	s.sort()
+	s.mu.Unlock()
+	s.table.Refresh()
+}
+
+// sortBy orders the rows by col, flipping the direction when it is already
+// the sort column
+func (s *screener) sortBy(col int) {
+	s.mu.Lock()
+	if col == s.sortCol {
+		s.desc = !s.desc
+	} else {
+		s.sortCol, s.desc = col, col != 0
+	}
+	s.sort()
+	s.mu.Unlock()
+	s.table.Refresh()
+}
+
+// sort orders the rows by the sort column, with empty cells last. The caller
+// holds mu
+func (s *screener) sort() {
+	slices.SortStableFunc(s.rows, func(a, b screenerRow) int {
+		if s.sortCol == 0 {
+			return s.direction(cmp.Compare(a.Symbol, b.Symbol))
+		}
+		x, y := a.Values[s.sortCol-1], b.Values[s.sortCol-1]
+		switch {
+		case math.IsNaN(x) && math.IsNaN(y):
+			return 0
+		case math.IsNaN(x):
+			return 1
+		case math.IsNaN(y):
+			return -1
+		}
+		return s.direction(cmp.Compare(x, y))
+	})
+}
+
+// direction turns an ascending comparison into the table's sort order
+func (s *screener) direction(c int) int {
+	if s.desc {
+		return -c
+	}
+	return c
+}
+
+// showScreener opens a window listing the watchlist with sortable columns,
+// kept current as the quotes come in until it is closed
+func showScreener(a fyne.App, watchlist *watchlistPanel) {
+	s := newScreener(watchlist)
+	s.SetQuotes(watchlist.Quotes())
+	remove := watchlist.OnQuote(func() {
+		s.SetQuotes(watchlist.Quotes())
+	})
+
+	w := a.NewWindow(lang.L("Watchlist Screener"))
+	w.SetOnClosed(remove)
+	w.SetContent(s.table)
+	w.Resize(fyne.NewSize(880, 400))
+	w.Show()
+}
@@ -0,0 +1,459 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"github.com/fsnotify/fsnotify"
+)
+
+// Settings holds the user configuration persisted between runs
+type Settings struct {
+	APIKey             string `json:"api_key"`
+	LookbackMonths     int    `json:"lookback_months"`      // history fetched, 0 for all of it
+	StaleAfterMinutes  int    `json:"stale_after_minutes"`  // 0 disables the stale badge
+	AutoRefreshMinutes int    `json:"auto_refresh_minutes"` // reload the current tab during market hours, 0 to turn off
+	RequestsPerHour    int    `json:"requests_per_hour"`    // API plan limits for the quota shown, 0 to hide
+	RequestsPerDay     int    `json:"requests_per_day"`
+	Model              string `json:"model"`
+	ForecastDays       int    `json:"forecast_days"`
+	VolatilityBand     bool   `json:"volatility_band"`
+	Baselines          bool   `json:"baselines"`
+	ChartStyle         string `json:"chart_style"` // "Line" or "Candles"
+	ShowVolume         bool   `json:"show_volume"`
+	ShowDrawdown       bool   `json:"show_drawdown"`
+	LogScale           bool   `json:"log_scale"`
+	PercentReturn      bool   `json:"percent_return"` // label the chart with the return instead of the price
+	ShowBenchmark      bool   `json:"show_benchmark"`
+	Benchmark          string `json:"benchmark"`    // symbol overlaid on the price chart
+	BarDays            int    `json:"bar_days"`     // trading days per chart bar
+	ChartWindow        string `json:"chart_window"` // history shown before zooming: "1M", "3M", "6M", "1Y" or "All"
+
+	Watchlist      []string  `json:"watchlist"`       // symbols in the sidebar, in order
+	RecentSymbols  []string  `json:"recent_symbols"`  // last fetched first
+	MovingAverages []string  `json:"moving_averages"` // e.g. "SMA 50" or "EMA 20"
+	Bollinger      Bollinger `json:"bollinger"`
+
+	Theme        string       `json:"theme"` // "System", "Dark" or "Light"
+	ChartPalette ChartPalette `json:"chart_palette"`
+	SeriesStyle  SeriesStyle  `json:"series_style"`
+	HighContrast bool         `json:"high_contrast"` // draw charts in bold colors on black, over the palette and series style
+
+	ARIMA          ARIMAOrder    `json:"arima"`            // order used when Model is "ARIMA"
+	RemoteModelURL string        `json:"remote_model_url"` // prediction service used when Model is "Remote server"
+	Preprocessing  Preprocessing `json:"preprocessing"`
+
+	Annotations   map[string][]Annotation `json:"annotations"`    // notes and levels drawn on each symbol's chart
+	SymbolRenames map[string]string       `json:"symbol_renames"` // old ticker to new ticker, on top of the built in ones
+	Currencies    map[string]string       `json:"currencies"`     // ISO currency of symbols not listed in USD
+
+	DailySummary bool      `json:"daily_summary"` // show the end of day card on launch
+	LastSummary  time.Time `json:"last_summary"`
+
+	Window WindowState `json:"window"` // layout of the main window when it was last closed
+}
+
+// WindowState is the size and layout of the main window. Fyne can't place
+// windows, so the position is left to the window manager
+type WindowState struct {
+	Width        float32  `json:"width"`
+	Height       float32  `json:"height"`
+	Tabs         []string `json:"tabs"`          // symbols of the open tabs, in order
+	SelectedTab  int      `json:"selected_tab"`  // index into Tabs
+	SidebarSplit float64  `json:"sidebar_split"` // watchlist share of the width
+	StatsSplit   float64  `json:"stats_split"`   // chart share of the width beside the stats panel
+}
+
+// Annotation is a note or price level the user pinned to a chart
+type Annotation struct {
+	Kind  string  `json:"kind"` // "note" or "level"
+	Date  string  `json:"date"`
+	Price float64 `json:"price"`
+	Text  string  `json:"text,omitempty"`
+}
+
+// ChartPalette overrides the chart colors, which otherwise follow the app
+// theme. Colors are "#rrggbb" or "#rrggbbaa"
+type ChartPalette struct {
+	Background string `json:"background"`
+	Foreground string `json:"foreground"` // titles, labels and axes
+}
+
+// SeriesStyle sets how the price and forecast lines are drawn. Colors are
+// "#rrggbb" or "#rrggbbaa"
+type SeriesStyle struct {
+	PriceColor       string  `json:"price_color"`
+	PredictionColor  string  `json:"prediction_color"`
+	LineWidth        float64 `json:"line_width"` // in points
+	DashedPrediction bool    `json:"dashed_prediction"`
+	Legend           string  `json:"legend"` // "top left", "top right", "bottom left" or "bottom right"
+}
+
+// Bollinger configures the Bollinger Bands drawn around the price
+type Bollinger struct {
+	Show       bool    `json:"show"`
+	Period     int     `json:"period"`     // bars in the moving average
+	Deviations float64 `json:"deviations"` // band width in standard deviations
+}
+
+// ARIMAOrder is the (p,d,q)(P,D,Q)[s] order of the ARIMA model
+type ARIMAOrder struct {
+	P int `json:"p"`
+	D int `json:"d"`
+	Q int `json:"q"`
+
+	SeasonalP int `json:"seasonal_p"`
+	SeasonalD int `json:"seasonal_d"`
+	SeasonalQ int `json:"seasonal_q"`
+	Period    int `json:"seasonal_period"` // 0 disables the seasonal part
+}
+
+// Preprocessing selects the transforms applied to prices before forecasting
+type Preprocessing struct {
+	Log        bool `json:"log"`
+	Difference bool `json:"difference"`
+	Winsorize  bool `json:"winsorize"`
+}
+
+// settingsBundle is the file format used to move settings between machines
+type settingsBundle struct {
+	Version  int       `json:"version"`
+	Exported time.Time `json:"exported"`
+	Settings Settings  `json:"settings"`
+}
+
+const settingsBundleVersion = 1
+
+// defaultSettings returns the settings used when no config file exists
+func defaultSettings() Settings {
+	return Settings{
+		APIKey:            apiKey,
+		LookbackMonths:    12,
+		StaleAfterMinutes: 60,
+		RequestsPerHour:   50, // Tiingo's free plan
+		RequestsPerDay:    1000,
+		Model:             "ARIMA",
+		ForecastDays:      30,
+		VolatilityBand:    true,
+		ChartStyle:        styleLine,
+		ShowVolume:        true,
+		BarDays:           1,
+		ChartWindow:       "3M",
+		Benchmark:         benchmarkSymbol,
+		Bollinger:         Bollinger{Period: 20, Deviations: 2},
+		Theme:             themeSystem,
+		SeriesStyle:       SeriesStyle{PriceColor: "#ff0000", PredictionColor: "#00ff00", LineWidth: 1, Legend: legendBottomRight},
+		ARIMA:             ARIMAOrder{P: 5, D: 1},
+		DailySummary:      true,
+		Window:            WindowState{Width: 800, Height: 600, SidebarSplit: 0.2, StatsSplit: 0.8},
+	}
+}
+
+// settingsStore guards the active settings and the file they are saved to
+type settingsStore struct {
+	mu        sync.RWMutex
+	path      string
+	cur       Settings
+	listeners []func(Settings)
+	reloaded  []func(Settings) // told when the settings come from outside the app's controls
+}
+
+// settings is the store shared by the whole app
+var settings *settingsStore
+
+// portableMarker is the file next to the executable that turns on portable mode
+const portableMarker = "portable.txt"
+
+// portableDir returns the executable's folder when running portable, asked
+// for on the command line or by a portable.txt beside the executable, or ""
+func portableDir(requested bool) string {
+	exe, err := os.Executable()
+	if err != nil {
+		if requested {
+			log.Println("Error finding executable, keeping portable data in the working directory:", err)
+			return "."
+		}
+		return ""
+	}
+	dir := filepath.Dir(exe)
+	if _, err := os.Stat(filepath.Join(dir, portableMarker)); requested || err == nil {
+		return dir
+	}
+	return ""
+}
+
+// defaultConfigPath returns the config file location, next to the executable
+// in portable mode and in the user's config directory otherwise
+func defaultConfigPath(portable string) string {
+	if portable != "" {
+		return filepath.Join(portable, "config.json")
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = "."
+	}
+	return filepath.Join(dir, "gomarket", "config.json")
+}
+
+// openSettings loads settings from path, falling back to defaults if the file is missing
+func openSettings(path string) (*settingsStore, error) {
+	s := &settingsStore{path: path, cur: defaultSettings()}
+	v, err := readSettings(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	s.cur = v
+	return s, nil
+}
+
+// readSettings parses a config file on top of the defaults
+func readSettings(path string) (Settings, error) {
+	v := defaultSettings()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return v, err
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return v, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	v.validate()
+	return v, nil
+}
+
+// validate brings hand-edited values back to ones the app offers:
+// lookback_months can't be negative, bar_days must be at least 1 and
+// forecast_days becomes the nearest of forecastHorizons, which the horizon
+// select can show
+func (v *Settings) validate() {
+	v.LookbackMonths = max(v.LookbackMonths, 0)
+	v.BarDays = max(v.BarDays, 1)
+	nearest := forecastHorizons[0]
+	for _, days := range forecastHorizons {
+		if max(days-v.ForecastDays, v.ForecastDays-days) < max(nearest-v.ForecastDays, v.ForecastDays-nearest) {
+			nearest = days
+		}
+	}
+	v.ForecastDays = nearest
+}
+
+// Get returns a copy of the current settings
+func (s *settingsStore) Get() Settings {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cur
+}
+
+// Set replaces the current settings, writes them to disk and notifies listeners
+func (s *settingsStore) Set(v Settings) error {
+	s.apply(v)
+	return s.save(v)
+}
+
+// OnChange registers fn to be called whenever the settings change
+func (s *settingsStore) OnChange(fn func(Settings)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, fn)
+}
+
+// OnReload registers fn to be called after the settings are replaced from a
+// file, either the config file edited on disk or an imported bundle, but not
+// when the app's own controls change them
+func (s *settingsStore) OnReload(fn func(Settings)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reloaded = append(s.reloaded, fn)
+}
+
+// apply makes v the current settings and notifies listeners, reporting
+// whether anything changed
+func (s *settingsStore) apply(v Settings) bool {
+	s.mu.Lock()
+	// Times read back from the file lose their monotonic clock reading and
+	// may come back in another location, so they are compared as instants
+	if v.LastSummary.Equal(s.cur.LastSummary) {
+		v.LastSummary = s.cur.LastSummary
+	}
+	if reflect.DeepEqual(s.cur, v) {
+		s.mu.Unlock()
+		return false
+	}
+	s.cur = v
+	listeners := append([]func(Settings){}, s.listeners...)
+	s.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn(v)
+	}
+	return true
+}
+
+// notifyReload tells the reload listeners about v
+func (s *settingsStore) notifyReload(v Settings) {
+	s.mu.RLock()
+	listeners := append([]func(Settings){}, s.reloaded...)
+	s.mu.RUnlock()
+
+	for _, fn := range listeners {
+		fn(v)
+	}
+}
+
+// Watch reloads the settings whenever the config file is changed on disk
+func (s *settingsStore) Watch() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	// Watch the directory rather than the file so editors that replace the file are picked up
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		watcher.Close()
+		return err
+	}
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+		var reload *time.Timer
+		for {
+			select {
+			case ev, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(ev.Name) != filepath.Clean(s.path) || ev.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				// Editors often write in several steps, so wait for them to settle
+				if reload != nil {
+					reload.Stop()
+				}
+				reload = time.AfterFunc(200*time.Millisecond, s.reload)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Println("Error watching config file:", err)
+			}
+		}
+	}()
+	return nil
+}
+
+// reload reads the config file again and applies it. The app's own saves
+// trigger this too, but they match the current settings and are ignored
+func (s *settingsStore) reload() {
+	v, err := readSettings(s.path)
+	if err != nil {
+		log.Println("Error reloading settings:", err)
+		return
+	}
+	if s.apply(v) {
+		s.notifyReload(v)
+	}
+}
+
+func (s *settingsStore) save(v Settings) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o600)
+}
+
+// Export writes the current settings as a versioned bundle
+func (s *settingsStore) Export(w io.Writer) error {
+	bundle := settingsBundle{
+		Version:  settingsBundleVersion,
+		Exported: time.Now(),
+		Settings: s.Get(),
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(bundle)
+}
+
+// Import reads a bundle written by Export and makes it the active settings
+func (s *settingsStore) Import(r io.Reader) error {
+	bundle := settingsBundle{Settings: defaultSettings()}
+	if err := json.NewDecoder(r).Decode(&bundle); err != nil {
+		return fmt.Errorf("reading settings bundle: %w", err)
+	}
+	if bundle.Version < 1 || bundle.Version > settingsBundleVersion {
+		return fmt.Errorf("unsupported settings bundle version %d", bundle.Version)
+	}
+	bundle.Settings.validate()
+	if err := s.Set(bundle.Settings); err != nil {
+		return err
+	}
+	s.notifyReload(bundle.Settings)
+	return nil
+}
+
+// exportSettings asks for a destination file and writes the settings bundle to it
+func exportSettings(win fyne.Window, status *statusBar) {
+	d := dialog.NewFileSave(func(w fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		if w == nil {
+			return // cancelled
+		}
+		defer w.Close()
+
+		if err := settings.Export(w); err != nil {
+			dialog.ShowError(err, win)
+			status.Set("Settings export failed: %v", err)
+			return
+		}
+		status.Set("Exported settings to %s", w.URI().Path())
+	}, win)
+	d.SetFileName("gomarket-settings.json")
+	d.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	d.Show()
+}
+
+// importSettings asks for a bundle written by exportSettings and applies it
+func importSettings(win fyne.Window, status *statusBar) {
+	d := dialog.NewFileOpen(func(r fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		if r == nil {
+			return // cancelled
+		}
+		defer r.Close()
+
+		if err := settings.Import(r); err != nil {
+			dialog.ShowError(err, win)
+			status.Set("Settings import failed: %v", err)
+			return
+		}
+		status.Set("Imported settings from %s", r.URI().Path())
+	}, win)
+	d.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	d.Show()
+}
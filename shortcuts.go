@@ -0,0 +1,45 @@
+package main
+
+import (
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+)
+
+// tabKeys pick the first nine tabs
+var tabKeys = []fyne.KeyName{fyne.Key1, fyne.Key2, fyne.Key3, fyne.Key4, fyne.Key5, fyne.Key6, fyne.Key7, fyne.Key8, fyne.Key9}
+
+// appShortcut returns the shortcut of key with Ctrl, or Cmd on macOS
+func appShortcut(key fyne.KeyName) *desktop.CustomShortcut {
+	return &desktop.CustomShortcut{KeyName: key, Modifier: fyne.KeyModifierShortcutDefault}
+}
+
+// isAppShortcut reports whether s is one of the window shortcuts added by
+// addShortcuts
+func isAppShortcut(s fyne.Shortcut) bool {
+	c, ok := s.(*desktop.CustomShortcut)
+	if !ok || c.Modifier != fyne.KeyModifierShortcutDefault {
+		return false
+	}
+	return c.KeyName == fyne.KeyR || c.KeyName == fyne.KeyL || slices.Contains(tabKeys, c.KeyName)
+}
+
+// addShortcuts registers the window's keyboard shortcuts: Ctrl+R refreshes
+// the current tab, Ctrl+L goes to the symbol field and Ctrl+1 to Ctrl+9
+// switch tabs
+func addShortcuts(win fyne.Window, entry *symbolEntry, tabs *workspace) {
+	c := win.Canvas()
+	c.AddShortcut(appShortcut(fyne.KeyR), func(fyne.Shortcut) { tabs.Refresh() })
+	c.AddShortcut(appShortcut(fyne.KeyL), func(fyne.Shortcut) {
+		c.Focus(entry)
+		entry.TypedShortcut(&fyne.ShortcutSelectAll{})
+	})
+	for i, key := range tabKeys {
+		c.AddShortcut(appShortcut(key), func(fyne.Shortcut) {
+			if i < len(tabs.tabs.Items) {
+				tabs.tabs.SelectIndex(i)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"sync/atomic"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Layouts of the side by side compare window
+const (
+	layoutStacked  = "Stacked"
+	layoutOverlaid = "Overlaid"
+)
+
+var compareLayouts = []string{layoutStacked, layoutOverlaid}
+
+// pairedCloses are the closes of two symbols on the dates both traded
+type pairedCloses struct {
+	Dates []string
+	A, B  []float64
+}
+
+// pairCloses lines a and b up on the dates they share, in the order of a
+func pairCloses(a, b []StockData) pairedCloses {
+	closes := make(map[string]float64, len(b))
+	for _, d := range b {
+		closes[d.Date] = d.Close
+	}
+	var p pairedCloses
+	for _, d := range a {
+		if c, ok := closes[d.Date]; ok && c > 0 && d.Close > 0 {
+			p.Dates = append(p.Dates, d.Date)
+			p.A = append(p.A, d.Close)
+			p.B = append(p.B, c)
+		}
+	}
+	return p
+}
+
+// visibleBars returns the first and last whole bars between start and end
+func visibleBars(start, end float64, n int) (first, last int) {
+	first = min(max(int(math.Ceil(start)), 0), n-1)
+	last = min(max(int(math.Floor(end)), first), n-1)
+	return first, last
+}
+
+// relativePerformance describes how a and b did over bars start to end: the
+// return of each and which one came out ahead, by how many percentage points
+func relativePerformance(a, b string, p pairedCloses, start, end float64) string {
+	if len(p.Dates) < 2 {
+		return ""
+	}
+	first, last := visibleBars(start, end, len(p.Dates))
+	ra := (p.A[last]/p.A[first] - 1) * 100
+	rb := (p.B[last]/p.B[first] - 1) * 100
+	leader, gap := a, ra-rb
+	if gap < 0 {
+		leader, gap = b, -gap
+	}
+	return fmt.Sprintf(lang.L("%s to %s: %s %s, %s %s, %s ahead by %s points"),
+		formatDay(p.Dates[first]), formatDay(p.Dates[last]), a, formatPercent(ra), b, formatPercent(rb), leader, formatNumber(gap, 2))
+}
+
+// showSideBySide opens a window charting two symbols over the same dates,
+// stacked or overlaid, with their zoom kept in step and a readout of how each
+// did over the visible range. The first symbol starts as v's
+func showSideBySide(a fyne.App, v *symbolView) {
+	var (
+		seq      int64
+		symbols  [2]string
+		paired   pairedCloses
+		charts   = [2]*chartWidget{newChartWidget(), newChartWidget()}
+		overlay  = newChartWidget()
+		readout  = widget.NewLabel(lang.L("Enter two symbols to compare"))
+		body     = container.NewStack()
+		progress = widget.NewProgressBarInfinite()
+		layouts  *localSelect
+	)
+	readout.TextStyle.Bold = true
+	readout.Wrapping = fyne.TextWrapWord
+	progress.Hide()
+
+	// Zooming or panning any chart moves the others with it
+	all := []*chartWidget{charts[0], charts[1], overlay}
+	for _, c := range all {
+		c.onRange = func(start, end float64) {
+			for _, other := range all {
+				if other != c {
+					other.SetRange(start, end)
+				}
+			}
+			readout.SetText(relativePerformance(symbols[0], symbols[1], paired, start, end))
+		}
+		c.onError = func(err error) {
+			v.status.Fail(err, "Plot failed for %s", symbols[0]+" / "+symbols[1])
+		}
+	}
+
+	show := func() {
+		if layouts.Value() == layoutOverlaid {
+			body.Objects = []fyne.CanvasObject{overlay}
+		} else {
+			body.Objects = []fyne.CanvasObject{container.NewGridWithRows(2, charts[0], charts[1])}
+		}
+		body.Refresh()
+	}
+	layouts = newLocalSelect(compareLayouts, func(string) { show() })
+
+	var entries [2]*symbolEntry
+	load := func() {
+		first := strings.ToUpper(strings.TrimSpace(entries[0].Text))
+		second := strings.ToUpper(strings.TrimSpace(entries[1].Text))
+		if first == "" || second == "" {
+			readout.SetText(lang.L("Enter two symbols to compare"))
+			return
+		}
+		n := atomic.AddInt64(&seq, 1)
+		progress.Show()
+		go func() {
+			defer func() {
+				if atomic.LoadInt64(&seq) == n {
+					progress.Hide()
+				}
+			}()
+			months := settings.Get().LookbackMonths
+			var data [2][]StockData
+			for i, symbol := range []string{first, second} {
+				d, err := fetchStockData(symbol, months)
+				if err != nil {
+					log.Println("Error fetching data:", err)
+					v.status.Fail(err, "Fetch failed for %s", symbol)
+					return
+				}
+				data[i] = d
+			}
+			if atomic.LoadInt64(&seq) != n {
+				return // a newer pair has been asked for
+			}
+
+			p := pairCloses(data[0], data[1])
+			if len(p.Dates) < 2 {
+				readout.SetText(fmt.Sprintf(lang.L("%s and %s have no trading days in common"), first, second))
+				return
+			}
+			symbols, paired = [2]string{first, second}, p
+			window := windowDays(settings.Get().ChartWindow)
+			for i, prices := range [2][]float64{p.A, p.B} {
+				charts[i].SetData(chartData{Symbol: symbols[i], Prices: prices, Dates: p.Dates, BarDays: 1, WindowDays: window, Percent: true})
+			}
+			overlay.SetData(chartData{Symbol: first, Prices: p.A, Dates: p.Dates, BarDays: 1, WindowDays: window, Percent: true, BenchmarkSymbol: second, Benchmark: p.B})
+			overlay.onRange(overlay.start, overlay.end)
+			v.status.Set("Compared %s and %s over %d common days", first, second, len(p.Dates))
+		}()
+	}
+	for i := range entries {
+		entries[i] = newSymbolEntry(func(string) { load() })
+	}
+	entries[0].SetPlaceHolder(lang.L("First symbol"))
+	entries[1].SetPlaceHolder(lang.L("Second symbol"))
+	if v.file == nil {
+		entries[0].SetText(v.symbol)
+	}
+
+	compare := widget.NewButton(lang.L("Compare"), load)
+	fields := container.NewGridWithColumns(2,
+		container.NewBorder(nil, nil, widget.NewLabel(lang.L("Symbol")), nil, entries[0]),
+		container.NewBorder(nil, nil, widget.NewLabel(lang.L("vs")), nil, entries[1]),
+	)
+	top := container.NewVBox(container.NewBorder(nil, nil, nil, container.NewHBox(layouts, compare, progress), fields), readout)
+
+	w := a.NewWindow(lang.L("Side by Side"))
+	w.SetContent(container.NewBorder(top, nil, nil, nil, body))
+	w.Resize(fyne.NewSize(900, 700))
+	layouts.SetValue(layoutStacked)
+	w.Show()
+	w.Canvas().Focus(entries[1])
+	load()
+}
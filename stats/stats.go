@@ -0,0 +1,107 @@
+// Package stats computes the summary statistics of a price history
+package stats
+
+import "math"
+
+// TradingDaysPerYear is the number of trading days in a year, used for
+// 52-week figures and to annualize volatility
+const TradingDaysPerYear = 252
+
+// AverageVolumeDays is the lookback of the average volume, about three months
+const AverageVolumeDays = 63
+
+// Bar is one day of a price history
+type Bar struct {
+	Open, High, Low, Close, Volume float64
+}
+
+// Summary holds the key figures of a price history
+type Summary struct {
+	LastClose     float64
+	Change        float64 // from the previous close
+	ChangePercent float64
+	PeriodReturn  float64 // percent, from the first close to the last
+	High52        float64
+	Low52         float64
+	AverageVolume float64 // daily, over the last AverageVolumeDays
+	Volatility    float64 // annualized percent over the last 52 weeks, NaN with too few bars
+}
+
+// Summarize computes the summary of bars, which are oldest first
+func Summarize(bars []Bar) Summary {
+	s := Summary{Volatility: math.NaN()}
+	if len(bars) == 0 {
+		return s
+	}
+	closes := make([]float64, len(bars))
+	for i, b := range bars {
+		closes[i] = b.Close
+	}
+	last := len(bars) - 1
+	s.LastClose = closes[last]
+	if last > 0 && closes[last-1] != 0 {
+		s.Change = s.LastClose - closes[last-1]
+		s.ChangePercent = s.Change / closes[last-1] * 100
+	}
+	s.PeriodReturn = PeriodReturn(closes)
+
+	year := bars[max(len(bars)-TradingDaysPerYear, 0):]
+	s.High52, s.Low52 = Range(year)
+	s.AverageVolume = AverageVolume(bars[max(len(bars)-AverageVolumeDays, 0):])
+	s.Volatility = AnnualizedVolatility(closes[len(closes)-len(year):])
+	return s
+}
+
+// PeriodReturn returns the percent change from the first close to the last,
+// zero if there are fewer than two or the first is zero
+func PeriodReturn(closes []float64) float64 {
+	if len(closes) < 2 || closes[0] == 0 {
+		return 0
+	}
+	return (closes[len(closes)-1] - closes[0]) / closes[0] * 100
+}
+
+// Range returns the highest high and the lowest low of bars
+func Range(bars []Bar) (high, low float64) {
+	if len(bars) == 0 {
+		return 0, 0
+	}
+	high, low = bars[0].High, bars[0].Low
+	for _, b := range bars[1:] {
+		high = max(high, b.High)
+		low = min(low, b.Low)
+	}
+	return high, low
+}
+
+// AverageVolume returns the mean daily volume of bars
+func AverageVolume(bars []Bar) float64 {
+	if len(bars) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, b := range bars {
+		total += b.Volume
+	}
+	return total / float64(len(bars))
+}
+
+// AnnualizedVolatility returns the annualized standard deviation of the daily
+// log returns of closes in percent, or NaN if there are fewer than two returns
+func AnnualizedVolatility(closes []float64) float64 {
+	if len(closes) < 3 {
+		return math.NaN()
+	}
+	returns := make([]float64, len(closes)-1)
+	mean := 0.0
+	for i := 1; i < len(closes); i++ {
+		returns[i-1] = math.Log(closes[i] / closes[i-1])
+		mean += returns[i-1]
+	}
+	mean /= float64(len(returns))
+	ss := 0.0
+	for _, r := range returns {
+		ss += (r - mean) * (r - mean)
+	}
+	return math.Sqrt(ss/float64(len(returns)-1)*TradingDaysPerYear) * 100
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	"gomarket/stats"
+)
+
+// summarizeData computes the summary stats of data, which is oldest first
+func summarizeData(data []StockData) stats.Summary {
+	bars := make([]stats.Bar, len(data))
+	for i, d := range data {
+		bars[i] = stats.Bar{Open: d.Open, High: d.High, Low: d.Low, Close: d.Close, Volume: d.Volume}
+	}
+	return stats.Summarize(bars)
+}
+
+// statsPanel shows the stats of a symbol beside its chart
+type statsPanel struct {
+	symbol  string
+	summary stats.Summary
+	values  []*widget.Label
+	content fyne.CanvasObject
+}
+
+// statsLabels name the rows of the stats panel
+var statsLabels = []string{"Last close", "Day change", "Period return", "52w high", "52w low", "Avg volume (3m)", "Volatility (1y)"}
+
+// newStatsPanel creates an empty stats panel
+func newStatsPanel() *statsPanel {
+	p := &statsPanel{}
+	form := container.New(layout.NewFormLayout())
+	for _, name := range statsLabels {
+		value := widget.NewLabel("–")
+		value.Alignment = fyne.TextAlignTrailing
+		p.values = append(p.values, value)
+		form.Add(widget.NewLabelWithStyle(lang.L(name), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+		form.Add(value)
+	}
+	p.content = container.NewVScroll(form)
+	return p
+}
+
+// SetData shows the stats of data for symbol
+func (p *statsPanel) SetData(symbol string, data []StockData) {
+	p.symbol = ""
+	if len(data) == 0 {
+		for _, value := range p.values {
+			value.SetText("–")
+		}
+		return
+	}
+	s := summarizeData(data)
+	p.symbol, p.summary = symbol, s
+	currency := symbolCurrency(symbol)
+	texts := []string{
+		formatPrice(s.LastClose, currency),
+		formatChange(s.Change, s.ChangePercent, currency),
+		formatPercent(s.PeriodReturn),
+		formatPrice(s.High52, currency),
+		formatPrice(s.Low52, currency),
+		formatCompact(s.AverageVolume),
+		numbers.Sprintf("%.1f%%", s.Volatility),
+	}
+	if math.IsNaN(s.Volatility) {
+		texts[len(texts)-1] = "–" // too few returns
+	}
+	for i, text := range texts {
+		p.values[i].SetText(text)
+	}
+}
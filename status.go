@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+)
+
+// statusBar shows the result of the last operation along the bottom of the
+// window with the data provider's usage beside it, and failures in a dialog
+// over it
+type statusBar struct {
+	label   *widget.Label
+	usage   *widget.Label
+	content fyne.CanvasObject
+	win     fyne.Window
+
+	mu      sync.Mutex
+	showing map[string]bool // failures with a dialog open, so repeats don't stack
+}
+
+// newStatusBar creates an empty status bar for win
+func newStatusBar(win fyne.Window) *statusBar {
+	label := widget.NewLabel(lang.L("Ready"))
+	label.Truncation = fyne.TextTruncateEllipsis
+	s := &statusBar{label: label, usage: widget.NewLabel(""), win: win, showing: map[string]bool{}}
+	s.content = container.NewBorder(nil, nil, nil, s.usage, label)
+	s.UpdateUsage()
+	return s
+}
+
+// UpdateUsage refreshes the provider, last fetch and quota shown
+func (s *statusBar) UpdateUsage() {
+	s.usage.SetText(usageText(settings.Get(), time.Now()))
+}
+
+// Set replaces the status text with a formatted message, translating format
+func (s *statusBar) Set(format string, args ...interface{}) {
+	s.label.SetText(fmt.Sprintf(lang.L(format), args...))
+}
+
+// Fail shows the formatted message and err in the status bar and opens an
+// error dialog saying what went wrong and, when known, how to fix it
+func (s *statusBar) Fail(err error, format string, args ...interface{}) {
+	msg := fmt.Sprintf(lang.L(format), args...)
+	s.label.SetText(fmt.Sprintf("%s: %v", msg, err))
+
+	text := fmt.Sprintf("%s.\n\n%v", msg, err)
+	if advice := errorAdvice(err); advice != "" {
+		text += "\n\n" + advice
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.win == nil || s.showing[text] {
+		return
+	}
+	s.showing[text] = true
+	d := dialog.NewError(errors.New(text), s.win)
+	d.SetOnClosed(func() {
+		s.mu.Lock()
+		delete(s.showing, text)
+		s.mu.Unlock()
+	})
+	d.Show()
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// summaryIndices are the index ETFs used to summarize the session
+var summaryIndices = []string{"SPY", "QQQ", "DIA", "IWM"}
+
+// lastMarketClose returns the most recent regular session close at or before t
+func lastMarketClose(t time.Time) time.Time {
+	t = t.In(marketLocation)
+	closeTime := time.Date(t.Year(), t.Month(), t.Day(), 16, 0, 0, 0, marketLocation)
+	if t.Before(closeTime) {
+		closeTime = closeTime.AddDate(0, 0, -1)
+	}
+	for closeTime.Weekday() == time.Saturday || closeTime.Weekday() == time.Sunday {
+		closeTime = closeTime.AddDate(0, 0, -1)
+	}
+	return closeTime
+}
+
+// showDailySummaryOnLaunch shows the summary card if a session has closed since it was last seen
+func showDailySummaryOnLaunch(win fyne.Window) {
+	s := settings.Get()
+	if !s.DailySummary || s.LastSummary.After(lastMarketClose(time.Now())) {
+		return
+	}
+	go showDailySummary(win)
+}
+
+// showDailySummary fetches the index moves of the last session and shows them in a card
+func showDailySummary(win fyne.Window) {
+	rows := []fyne.CanvasObject{}
+	session := ""
+	for _, symbol := range summaryIndices {
+		data, err := fetchStockData(symbol, 1)
+		if err != nil || len(data) < 2 {
+			log.Println("Error fetching summary data for", symbol, err)
+			continue
+		}
+		last, prev := data[len(data)-1], data[len(data)-2]
+		diff := last.Close - prev.Close
+		session = last.Date
+
+		change := canvas.NewText(formatPercent(diff/prev.Close*100), directionColor(diff))
+		change.Alignment = fyne.TextAlignTrailing
+		rows = append(rows,
+			widget.NewLabelWithStyle(symbol, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			widget.NewLabelWithStyle(formatPrice(last.Close, symbolCurrency(symbol)), fyne.TextAlignTrailing, fyne.TextStyle{}),
+			change,
+		)
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	if t, err := time.Parse(time.RFC3339, session); err == nil {
+		session = formatDate(t, "Mon Jan 2")
+	}
+	title := canvas.NewText(fmt.Sprintf(lang.L("Market summary for %s"), session), theme.ForegroundColor())
+	title.TextStyle.Bold = true
+	content := container.NewVBox(title, container.NewGridWithColumns(3, rows...))
+	dialog.ShowCustom(lang.L("End of day summary"), lang.L("Close"), content, win)
+
+	s := settings.Get()
+	s.LastSummary = time.Now()
+	if err := settings.Set(s); err != nil {
+		log.Println("Error saving settings:", err)
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// symbolEntry is the symbol field, listing matching tickers under it as the
+// user types. Enter or a click on a suggestion picks it
+type symbolEntry struct {
+	widget.Entry
+
+	onSubmit func(symbol string)
+	matches  []symbolInfo
+	list     *suggestionList
+	popup    *widget.PopUp
+}
+
+// newSymbolEntry creates a symbol field that calls onSubmit with the chosen
+// or typed symbol
+func newSymbolEntry(onSubmit func(string)) *symbolEntry {
+	e := &symbolEntry{onSubmit: onSubmit}
+	e.ExtendBaseWidget(e)
+	e.list = newSuggestionList(e)
+	e.OnChanged = e.suggest
+	e.OnSubmitted = func(text string) {
+		e.hideSuggestions()
+		e.onSubmit(strings.ToUpper(strings.TrimSpace(text)))
+	}
+	return e
+}
+
+// TypedShortcut implements fyne.Shortcutable, passing the window's own
+// shortcuts on so they work while typing a symbol
+func (e *symbolEntry) TypedShortcut(s fyne.Shortcut) {
+	if !isAppShortcut(s) {
+		e.Entry.TypedShortcut(s)
+		return
+	}
+	if c, ok := fyne.CurrentApp().Driver().CanvasForObject(e).(fyne.Shortcutable); ok {
+		c.TypedShortcut(s)
+	}
+}
+
+// suggest lists the known symbols matching text below the entry
+func (e *symbolEntry) suggest(text string) {
+	e.matches = matchSymbols(text)
+	if len(e.matches) == 0 {
+		e.hideSuggestions()
+		return
+	}
+	c := fyne.CurrentApp().Driver().CanvasForObject(e)
+	if c == nil {
+		return
+	}
+
+	e.list.highlighted = -1
+	e.list.UnselectAll()
+	e.list.Refresh()
+	if e.popup == nil {
+		e.popup = widget.NewPopUp(e.list, c)
+	}
+	row := widget.NewLabel("").MinSize().Height + theme.SeparatorThicknessSize()
+	e.popup.Resize(fyne.NewSize(e.Size().Width, row*float32(len(e.matches))))
+	e.popup.ShowAtRelativePosition(fyne.NewPos(0, e.Size().Height), e)
+	c.Focus(e.list)
+}
+
+// choose fills in symbol and submits it
+func (e *symbolEntry) choose(symbol string) {
+	e.OnChanged = nil
+	e.SetText(symbol)
+	e.CursorColumn = len(symbol)
+	e.Refresh()
+	e.OnChanged = e.suggest
+	e.hideSuggestions()
+	e.onSubmit(symbol)
+}
+
+// hideSuggestions closes the list of suggestions, giving the keyboard back
+// to the entry
+func (e *symbolEntry) hideSuggestions() {
+	if e.popup == nil || !e.popup.Visible() {
+		return
+	}
+	e.popup.Hide()
+	if c := fyne.CurrentApp().Driver().CanvasForObject(e); c != nil {
+		c.Focus(e)
+	}
+}
+
+// suggestionList is the list under a symbolEntry. It has the keyboard focus
+// while it is shown, using the arrow keys, Enter and Escape itself and
+// passing the rest of the typing on to the entry
+type suggestionList struct {
+	widget.List
+
+	entry       *symbolEntry
+	highlighted widget.ListItemID // row picked by Enter, -1 for the typed text
+	navigating  bool              // the selection is moving with the arrow keys, not picking
+}
+
+// newSuggestionList creates the suggestion list of e
+func newSuggestionList(e *symbolEntry) *suggestionList {
+	l := &suggestionList{entry: e, highlighted: -1}
+	l.Length = func() int { return len(e.matches) }
+	l.CreateItem = func() fyne.CanvasObject { return widget.NewLabel("") }
+	l.UpdateItem = func(id widget.ListItemID, o fyne.CanvasObject) {
+		if id < len(e.matches) {
+			o.(*widget.Label).SetText(e.matches[id].Ticker + "  " + e.matches[id].Name)
+		}
+	}
+	l.OnSelected = func(id widget.ListItemID) {
+		if !l.navigating && id < len(e.matches) {
+			e.choose(e.matches[id].Ticker)
+		}
+	}
+	l.ExtendBaseWidget(l)
+	return l
+}
+
+// move highlights the suggestion step rows away from the current one
+func (l *suggestionList) move(step int) {
+	id := min(max(l.highlighted+step, 0), len(l.entry.matches)-1)
+	if id < 0 {
+		return
+	}
+	l.highlighted = id
+	l.navigating = true
+	l.Select(id)
+	l.navigating = false
+}
+
+// TypedKey implements fyne.Focusable
+func (l *suggestionList) TypedKey(key *fyne.KeyEvent) {
+	switch key.Name {
+	case fyne.KeyDown:
+		l.move(1)
+	case fyne.KeyUp:
+		l.move(-1)
+	case fyne.KeyReturn, fyne.KeyEnter:
+		if l.highlighted >= 0 && l.highlighted < len(l.entry.matches) {
+			l.entry.choose(l.entry.matches[l.highlighted].Ticker)
+		} else {
+			l.entry.OnSubmitted(l.entry.Text)
+		}
+	case fyne.KeyEscape:
+		l.entry.hideSuggestions()
+	default:
+		l.entry.TypedKey(key)
+	}
+}
+
+// TypedRune implements fyne.Focusable
+func (l *suggestionList) TypedRune(r rune) {
+	l.entry.TypedRune(r)
+}
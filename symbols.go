@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strings"
+)
+
+// symbolInfo is a ticker offered as a suggestion while typing a symbol
+type symbolInfo struct {
+	Ticker string
+	Name   string
+}
+
+// knownSymbols are the bundled suggestions: large US companies and the
+// funds used as benchmarks
+var knownSymbols = []symbolInfo{
+	{"AAPL", "Apple Inc."}, {"ABBV", "AbbVie Inc."}, {"ADBE", "Adobe Inc."}, {"AMD", "Advanced Micro Devices"},
+	{"AMT", "American Tower Corp."}, {"AMZN", "Amazon.com Inc."}, {"AVGO", "Broadcom Inc."}, {"BA", "Boeing Co."},
+	{"BAC", "Bank of America Corp."}, {"BRK-B", "Berkshire Hathaway Class B"}, {"CAT", "Caterpillar Inc."}, {"COP", "ConocoPhillips"},
+	{"COST", "Costco Wholesale Corp."}, {"CRM", "Salesforce Inc."}, {"CSCO", "Cisco Systems Inc."}, {"CVX", "Chevron Corp."},
+	{"DIS", "Walt Disney Co."}, {"DOW", "Dow Inc."}, {"DUK", "Duke Energy Corp."}, {"GE", "General Electric Co."},
+	{"GOOG", "Alphabet Inc. Class C"}, {"GOOGL", "Alphabet Inc. Class A"}, {"GS", "Goldman Sachs Group Inc."}, {"HD", "Home Depot Inc."},
+	{"HON", "Honeywell International Inc."}, {"IBM", "International Business Machines"}, {"INTC", "Intel Corp."}, {"JNJ", "Johnson & Johnson"},
+	{"JPM", "JPMorgan Chase & Co."}, {"KO", "Coca-Cola Co."}, {"LIN", "Linde plc"}, {"LLY", "Eli Lilly and Co."},
+	{"MA", "Mastercard Inc."}, {"MCD", "McDonald's Corp."}, {"META", "Meta Platforms Inc."}, {"MRK", "Merck & Co."},
+	{"MS", "Morgan Stanley"}, {"MSFT", "Microsoft Corp."}, {"NEE", "NextEra Energy Inc."}, {"NFLX", "Netflix Inc."},
+	{"NKE", "Nike Inc."}, {"NVDA", "NVIDIA Corp."}, {"ORCL", "Oracle Corp."}, {"PEP", "PepsiCo Inc."},
+	{"PFE", "Pfizer Inc."}, {"PG", "Procter & Gamble Co."}, {"PLD", "Prologis Inc."}, {"PYPL", "PayPal Holdings Inc."},
+	{"QCOM", "Qualcomm Inc."}, {"SBUX", "Starbucks Corp."}, {"SLB", "Schlumberger Ltd."}, {"SO", "Southern Co."},
+	{"T", "AT&T Inc."}, {"TSLA", "Tesla Inc."}, {"UNH", "UnitedHealth Group Inc."}, {"UPS", "United Parcel Service Inc."},
+	{"V", "Visa Inc."}, {"VZ", "Verizon Communications Inc."}, {"WFC", "Wells Fargo & Co."}, {"WMT", "Walmart Inc."},
+	{"XOM", "Exxon Mobil Corp."},
+	{"DIA", "SPDR Dow Jones Industrial Average ETF"}, {"IWM", "iShares Russell 2000 ETF"}, {"QQQ", "Invesco QQQ Trust"},
+	{"SPY", "SPDR S&P 500 ETF"}, {"VTI", "Vanguard Total Stock Market ETF"}, {"XLB", "Materials Select Sector SPDR"},
+	{"XLC", "Communication Services Select Sector SPDR"}, {"XLE", "Energy Select Sector SPDR"}, {"XLF", "Financial Select Sector SPDR"},
+	{"XLI", "Industrial Select Sector SPDR"}, {"XLK", "Technology Select Sector SPDR"}, {"XLP", "Consumer Staples Select Sector SPDR"},
+	{"XLRE", "Real Estate Select Sector SPDR"}, {"XLU", "Utilities Select Sector SPDR"}, {"XLV", "Health Care Select Sector SPDR"},
+	{"XLY", "Consumer Discretionary Select Sector SPDR"},
+}
+
+// maxSuggestions caps the tickers listed under the symbol entry
+const maxSuggestions = 8
+
+// matchSymbols returns the known symbols whose ticker starts with text,
+// followed by those whose company name contains it
+func matchSymbols(text string) []symbolInfo {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	upper, lower := strings.ToUpper(text), strings.ToLower(text)
+
+	var tickers, names []symbolInfo
+	for _, s := range knownSymbols {
+		switch {
+		case strings.HasPrefix(s.Ticker, upper):
+			tickers = append(tickers, s)
+		case strings.Contains(strings.ToLower(s.Name), lower):
+			names = append(names, s)
+		}
+	}
+	matches := append(tickers, names...)
+	return matches[:min(len(matches), maxSuggestions)]
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"fyne.io/fyne/v2/lang"
+	"gonum.org/v1/plot"
+)
+
+// barSizes are the bar lengths offered in the UI, in trading days; any other
+// positive value can be set as bar_days in the config file
+var barSizes = []int{1, 2, 3, 5, 10}
+
+// lookbackPeriod is how much history is fetched for a symbol
+type lookbackPeriod struct {
+	Label  string
+	Months int // 0 for all the history the provider has
+}
+
+// lookbackPeriods are the periods offered in the UI; any other number of
+// months can be set as lookback_months in the config file
+var lookbackPeriods = []lookbackPeriod{{"3M", 3}, {"6M", 6}, {"1Y", 12}, {"2Y", 24}, {"5Y", 60}, {"Max", 0}}
+
+// lookbackLabel names a lookback of months, e.g. "1Y" or "18M"
+func lookbackLabel(months int) string {
+	for _, p := range lookbackPeriods {
+		if p.Months == months {
+			return p.Label
+		}
+	}
+	return fmt.Sprintf("%dM", months)
+}
+
+// chartWindow is a span of history the chart opens on
+type chartWindow struct {
+	Label string
+	Days  int // trading days, 0 for everything fetched
+}
+
+// chartWindows are the spans offered in the UI
+var chartWindows = []chartWindow{{"1M", 21}, {"3M", 63}, {"6M", 126}, {"1Y", 252}, {"All", 0}}
+
+// windowLabels returns the labels of chartWindows
+func windowLabels() []string {
+	labels := make([]string, len(chartWindows))
+	for i, w := range chartWindows {
+		labels[i] = w.Label
+	}
+	return labels
+}
+
+// windowDays returns the trading days of the window called label, 0 for all or an unknown label
+func windowDays(label string) int {
+	for _, w := range chartWindows {
+		if w.Label == label {
+			return w.Days
+		}
+	}
+	return 0
+}
+
+// barLabel names a bar size, e.g. "Daily" or "2-day bars"
+func barLabel(days int) string {
+	if days <= 1 {
+		return lang.L("Daily")
+	}
+	return fmt.Sprintf(lang.L("%d-day bars"), days)
+}
+
+// aggregateBars combines every n daily bars into one, counting back from the
+// latest so the last bar is always complete. The first bar may cover fewer days.
+func aggregateBars(data []StockData, n int) []StockData {
+	if n <= 1 || len(data) == 0 {
+		return data
+	}
+
+	var out []StockData
+	start := len(data) % n
+	if start > 0 {
+		out = append(out, combineBars(data[:start]))
+	}
+	for i := start; i < len(data); i += n {
+		out = append(out, combineBars(data[i:i+n]))
+	}
+	return out
+}
+
+// combineBars merges consecutive bars into one dated at the first of them
+func combineBars(group []StockData) StockData {
+	bar := group[0]
+	for _, b := range group[1:] {
+		if b.High > bar.High {
+			bar.High = b.High
+		}
+		if b.Low < bar.Low {
+			bar.Low = b.Low
+		}
+		bar.Volume += b.Volume
+	}
+	bar.Close = group[len(group)-1].Close
+	return bar
+}
+
+// shortDate trims a Tiingo timestamp such as "2024-05-01T00:00:00.000Z" to its date
+func shortDate(date string) string {
+	if len(date) >= 10 {
+		return date[:10]
+	}
+	return date
+}
+
+// addTradingDays returns the date n weekdays after t, ignoring market holidays
+func addTradingDays(t time.Time, n int) time.Time {
+	for n > 0 {
+		t = t.AddDate(0, 0, 1)
+		if t.Weekday() != time.Saturday && t.Weekday() != time.Sunday {
+			n--
+		}
+	}
+	return t
+}
+
+// barTime returns the date of bar i, projecting barDays trading days per bar
+// past the last date for forecast bars
+func barTime(dates []string, barDays, i int) (time.Time, bool) {
+	if len(dates) == 0 || i < 0 {
+		return time.Time{}, false
+	}
+	if i < len(dates) {
+		t, err := time.Parse("2006-01-02", shortDate(dates[i]))
+		return t, err == nil
+	}
+	last, err := time.Parse("2006-01-02", shortDate(dates[len(dates)-1]))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return addTradingDays(last, (i-len(dates)+1)*max(barDays, 1)), true
+}
+
+// dateTicks labels the bar positions of a chart with their dates
+type dateTicks struct {
+	dates   []string
+	barDays int
+}
+
+// Ticks implements plot.Ticker
+func (t dateTicks) Ticks(min, max float64) []plot.Tick {
+	layout := "Jan 2"
+	if (max-min)*float64(t.barDays) > tradingDaysPerYear {
+		layout = "Jan 2006"
+	}
+
+	ticks := plot.DefaultTicks{}.Ticks(min, max)
+	for i, tick := range ticks {
+		if tick.Label == "" {
+			continue
+		}
+		date, ok := barTime(t.dates, t.barDays, int(tick.Value))
+		if !ok || tick.Value != math.Trunc(tick.Value) {
+			ticks[i].Label = ""
+			continue
+		}
+		ticks[i].Label = formatDate(date, layout)
+	}
+	return ticks
+}
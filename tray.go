@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/lang"
+)
+
+// trayQuoteText describes the top symbol of the watchlist for the tray menu
+func trayQuoteText(w *watchlistPanel) string {
+	symbol, q, ok := w.Top()
+	switch {
+	case symbol == "":
+		return lang.L("Watchlist is empty")
+	case !ok:
+		return symbol + " …"
+	}
+	return fmt.Sprintf("%s %s %s", symbol, formatPrice(q.Close, symbolCurrency(symbol)), formatPercent(q.Percent))
+}
+
+// setupTray adds a tray icon whose menu shows the watchlist's top quote and
+// can show or hide win and refresh the quotes and the current tab. The tray
+// has no tooltip in this Fyne version, so the quote is the menu's first item
+func setupTray(a fyne.App, win fyne.Window, watchlist *watchlistPanel, tabs *workspace) {
+	desk, ok := a.(desktop.App)
+	if !ok {
+		return
+	}
+	quote := fyne.NewMenuItem(trayQuoteText(watchlist), nil)
+	quote.Disabled = true
+	menu := fyne.NewMenu(a.Metadata().Name,
+		quote,
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem(lang.L("Show Window"), func() {
+			win.Show()
+			win.RequestFocus()
+		}),
+		fyne.NewMenuItem(lang.L("Hide Window"), win.Hide),
+		fyne.NewMenuItem(lang.L("Refresh"), func() {
+			watchlist.Refresh()
+			tabs.Refresh()
+		}),
+	)
+	watchlist.OnQuote(func() {
+		quote.Label = trayQuoteText(watchlist)
+		menu.Refresh()
+	})
+	desk.SetSystemTrayMenu(menu)
+}
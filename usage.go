@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2/lang"
+)
+
+// providerName is the data provider shown in the status bar
+const providerName = "Tiingo"
+
+// apiUsage counts the requests made to the provider, which doesn't report
+// the quota left, so the remaining requests can be estimated against the
+// plan's limits
+type apiUsage struct {
+	mu       sync.Mutex
+	requests []time.Time // within the last day, oldest first
+	onChange func()
+}
+
+// usage counts the requests of this run of the app
+var usage = &apiUsage{}
+
+// Record notes a request made now
+func (u *apiUsage) Record() {
+	u.mu.Lock()
+	now := time.Now()
+	u.requests = append(u.prune(now), now)
+	fn := u.onChange
+	u.mu.Unlock()
+	if fn != nil {
+		fn()
+	}
+}
+
+// prune drops the requests older than a day, the caller holds mu
+func (u *apiUsage) prune(now time.Time) []time.Time {
+	i := 0
+	for i < len(u.requests) && now.Sub(u.requests[i]) >= 24*time.Hour {
+		i++
+	}
+	return u.requests[i:]
+}
+
+// Counts returns the requests made in the last hour and day, and the time of
+// the latest, zero if there has been none
+func (u *apiUsage) Counts(now time.Time) (hour, day int, last time.Time) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.requests = u.prune(now)
+	for _, t := range u.requests {
+		if now.Sub(t) < time.Hour {
+			hour++
+		}
+	}
+	if len(u.requests) > 0 {
+		last = u.requests[len(u.requests)-1]
+	}
+	return hour, len(u.requests), last
+}
+
+// usageText describes the provider, the last fetch and the requests left
+// under the limits in s
+func usageText(s Settings, now time.Time) string {
+	hour, day, last := usage.Counts(now)
+	parts := []string{providerName}
+	if last.IsZero() {
+		parts = append(parts, lang.L("nothing fetched yet"))
+	} else {
+		parts = append(parts, fmt.Sprintf(lang.L("last fetch %s"), last.Format("15:04:05")))
+	}
+	if s.RequestsPerHour > 0 {
+		parts = append(parts, fmt.Sprintf(lang.L("%d/%d left this hour"), max(s.RequestsPerHour-hour, 0), s.RequestsPerHour))
+	}
+	if s.RequestsPerDay > 0 {
+		parts = append(parts, fmt.Sprintf(lang.L("%d/%d in 24h"), max(s.RequestsPerDay-day, 0), s.RequestsPerDay))
+	}
+	return strings.Join(parts, " · ")
+}
@@ -0,0 +1,652 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"math"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"gonum.org/v1/plot"
+)
+
+// symbolView shows the chart and forecast for one symbol along with its own refresh controls
+type symbolView struct {
+	status *statusBar
+
+	symbol    string
+	file      []StockData // bars read from a dropped CSV named symbol, charted instead of fetching
+	loaded    []StockData // the bars of the last load, drawn again when the chart's overlays change
+	benchmark []StockData // the benchmark's bars, kept until the next fetch
+	benchSym  string      // the symbol of benchmark
+	drawn     string      // the chart style the chart was last drawn in
+	barDays   int         // the bar size the chart was last drawn with
+	fetchedAt time.Time
+	lastClose float64
+	prices    []float64
+	anomalies []anomaly
+	seq       int64       // identifies the latest load so a slow forecast can't overwrite a newer chart
+	busy      bool        // a load is running
+	onBusy    func(bool)  // told when a load starts and when it finishes
+	popout    fyne.Window // the window the view was moved into, nil while it is in its tab
+	onPopOut  func()      // moves the view into a window of its own or back into its tab
+
+	chart    *fyne.Container
+	data     *dataTable
+	stats    *statsPanel
+	split    *container.Split
+	plot     *chartWidget
+	price    *canvas.Text
+	change   *canvas.Text
+	flash    *canvas.Rectangle
+	heat     *heatStrip
+	metrics  *widget.Label
+	mode     *localSelect
+	style    *localSelect
+	bars     *widget.Select
+	window   *widget.Select
+	sector   *widget.Entry
+	compare  *widget.Entry
+	age      *widget.Label
+	stale    *widget.Label
+	refresh  *widget.Button
+	detach   *widget.Button
+	progress *widget.ProgressBarInfinite
+	content  fyne.CanvasObject
+}
+
+// newSymbolView creates an empty view
+func newSymbolView(status *statusBar) *symbolView {
+	v := &symbolView{status: status}
+
+	v.plot = newChartWidget()
+	v.plot.onError = func(err error) {
+		status.Fail(err, "Plot failed for %s", v.symbol)
+	}
+	v.plot.onAnnotate = func(list []Annotation) {
+		saveAnnotations(v.symbol, list)
+	}
+	v.chart = container.NewStack()
+	v.data = newDataTable()
+	v.stats = newStatsPanel()
+
+	v.price = canvas.NewText("", theme.ForegroundColor())
+	v.price.TextStyle.Bold = true
+	v.change = canvas.NewText("", theme.ForegroundColor())
+	v.flash = canvas.NewRectangle(color.Transparent)
+	v.heat = newHeatStrip(quoteHeatBlock)
+	quote := container.NewHBox(container.NewStack(v.flash, container.NewPadded(v.price)), v.change, container.NewCenter(v.heat.box))
+
+	v.age = widget.NewLabel(lang.L("No data loaded"))
+	v.stale = widget.NewLabel(lang.L("STALE"))
+	v.stale.Importance = widget.DangerImportance
+	v.stale.Hide()
+	v.refresh = widget.NewButton(lang.L("Refresh"), func() { v.Load(v.symbol) })
+	v.refresh.Disable()
+	v.detach = widget.NewButtonWithIcon(lang.L("Pop Out"), theme.ViewFullScreenIcon(), func() {
+		if v.onPopOut != nil {
+			v.onPopOut()
+		}
+	})
+	v.progress = widget.NewProgressBarInfinite()
+	v.progress.Hide()
+	v.metrics = widget.NewLabel("")
+
+	v.sector = widget.NewEntry()
+	v.sector.SetPlaceHolder(lang.L("Sector ETF"))
+	v.sector.Hide()
+	v.compare = widget.NewEntry()
+	v.compare.SetPlaceHolder(lang.L("Compare with, e.g. MSFT, SPY"))
+	v.compare.Hide()
+	v.mode = newLocalSelect(chartModes, func(mode string) {
+		if mode == modeSectorRelative {
+			v.sector.Show()
+		} else {
+			v.sector.Hide()
+		}
+		if mode == modeCompare {
+			v.compare.Show()
+		} else {
+			v.compare.Hide()
+		}
+		if v.symbol != "" {
+			v.Load(v.symbol)
+		}
+	})
+	v.mode.SetValue(modePrice)
+	v.style = newLocalSelect(chartStyles, func(style string) {
+		if s := settings.Get(); s.ChartStyle != style {
+			s.ChartStyle = style
+			if err := settings.Set(s); err != nil {
+				log.Println("Error saving settings:", err)
+			}
+		}
+		if style != v.drawn {
+			v.Redraw()
+		}
+	})
+	v.style.SetValue(settings.Get().ChartStyle)
+
+	sizes := barSizes
+	if days := settings.Get().BarDays; days > 1 && !slices.Contains(sizes, days) {
+		sizes = append(append([]int(nil), sizes...), days)
+	}
+	labels := make([]string, len(sizes))
+	for i, days := range sizes {
+		labels[i] = barLabel(days)
+	}
+	v.bars = widget.NewSelect(labels, func(label string) {
+		i := slices.Index(labels, label)
+		if i < 0 {
+			return
+		}
+		if s := settings.Get(); s.BarDays != sizes[i] {
+			s.BarDays = sizes[i]
+			if err := settings.Set(s); err != nil {
+				log.Println("Error saving settings:", err)
+			}
+		}
+		if sizes[i] != v.barDays {
+			v.Redraw()
+		}
+	})
+	v.bars.SetSelected(barLabel(settings.Get().BarDays))
+	v.window = widget.NewSelect(windowLabels(), func(label string) {
+		if s := settings.Get(); s.ChartWindow != label {
+			s.ChartWindow = label
+			if err := settings.Set(s); err != nil {
+				log.Println("Error saving settings:", err)
+			}
+		}
+		// Setting the window undoes any zoom, so it is left alone when the
+		// settings listener sets the window already shown
+		if days := windowDays(label); days != v.plot.data.WindowDays {
+			v.plot.SetWindow(days)
+		}
+	})
+	v.window.SetSelected(settings.Get().ChartWindow)
+	v.sector.OnSubmitted = func(string) {
+		if v.symbol != "" {
+			v.Load(v.symbol)
+		}
+	}
+	v.compare.OnSubmitted = v.sector.OnSubmitted
+
+	header := container.NewHBox(quote, v.age, v.stale, layout.NewSpacer(), v.sector, v.compare, v.window, v.bars, v.style, v.mode, v.refresh, v.detach, v.progress)
+	views := container.NewAppTabs(container.NewTabItem(lang.L("Chart"), v.chart), container.NewTabItem(lang.L("Data"), v.data.table))
+	views.SetTabLocation(container.TabLocationBottom)
+	v.split = container.NewHSplit(views, v.stats.content)
+	v.split.Offset = settings.Get().Window.StatsSplit
+	v.content = container.NewBorder(container.NewVBox(header, v.metrics), nil, nil, nil, v.split)
+	return v
+}
+
+// showChart replaces the chart with the interactive price chart of c
+func (v *symbolView) showChart(c chartData) {
+	v.plot.SetData(c)
+	v.chart.Objects = []fyne.CanvasObject{v.plot}
+	v.chart.Refresh()
+}
+
+// showPlot replaces the chart with a static plot
+func (v *symbolView) showPlot(p *plot.Plot) {
+	img := newPlotImage(p.Draw)
+	img.onError = func(err error) {
+		v.status.Fail(err, "Plot failed for %s", v.symbol)
+	}
+	v.chart.Objects = []fyne.CanvasObject{img}
+	v.chart.Refresh()
+}
+
+// showText replaces the chart with text, for when it can't be drawn
+func (v *symbolView) showText(text string) {
+	label := widget.NewLabel(text)
+	label.TextStyle.Monospace = true
+	v.chart.Objects = []fyne.CanvasObject{container.NewScroll(label)}
+	v.chart.Refresh()
+}
+
+// Load fetches symbol in the background, showing progress and keeping the
+// fetch buttons disabled until its chart and forecast are drawn
+func (v *symbolView) Load(symbol string) {
+	seq := atomic.AddInt64(&v.seq, 1)
+	v.setBusy(true)
+	v.status.Set("Fetching %s...", symbol)
+	go func() {
+		v.load(symbol, seq)
+		if atomic.LoadInt64(&v.seq) == seq {
+			v.setBusy(false)
+		}
+	}()
+}
+
+// LoadFile charts the bars of a CSV file called name in place of fetched data,
+// so they get the same chart, forecast and stats as a symbol
+func (v *symbolView) LoadFile(name string, data []StockData) {
+	v.symbol = name
+	v.file = data
+	v.Load(name)
+}
+
+// setBusy shows or hides the progress bar and turns the fetch buttons off
+// while a load runs
+func (v *symbolView) setBusy(busy bool) {
+	v.busy = busy
+	if busy {
+		v.refresh.Disable()
+		v.progress.Show()
+	} else {
+		if v.symbol != "" {
+			v.refresh.Enable()
+		}
+		v.progress.Hide()
+	}
+	if v.onBusy != nil {
+		v.onBusy(busy)
+	}
+}
+
+// load draws the price history of symbol and then adds the forecast. It runs
+// off the UI goroutine and gives up once a newer load, seq, has started
+func (v *symbolView) load(symbol string, seq int64) {
+	status := v.status
+	start := time.Now()
+	data := v.file
+	if data == nil {
+		if current, ok := resolveSymbol(symbol); ok {
+			log.Printf("%s now trades as %s\n", symbol, current)
+			status.Set("%s now trades as %s, loading %s", symbol, current, current)
+			symbol = current
+		}
+		var err error
+		data, err = fetchStockData(symbol, settings.Get().LookbackMonths)
+		if err != nil {
+			log.Println("Error fetching data:", err)
+			status.Fail(err, "Fetch failed for %s", symbol)
+			return
+		}
+	}
+	if atomic.LoadInt64(&v.seq) != seq {
+		return // a newer fetch has replaced this one
+	}
+
+	log.Printf("Fetched %d data points for symbol: %s\n", len(data), symbol)
+
+	if len(data) == 0 {
+		log.Println("No data returned for symbol:", symbol)
+		status.Set("No data returned for %s", symbol)
+		return
+	}
+
+	v.showQuote(symbol, data)
+	if v.file == nil {
+		go v.loadIntraday(symbol)
+	}
+	if symbol != v.symbol {
+		// Refreshes leave the history alone, as saving it from every tab's
+		// load would reorder it and notify every tab again
+		if v.file == nil {
+			rememberSymbol(symbol)
+		}
+		v.sector.SetText(sectorETF(symbol))
+	}
+	v.symbol = symbol
+	v.fetchedAt = time.Now()
+	v.UpdateAge()
+	v.loaded, v.benchmark = data, nil
+	v.draw(symbol, data, seq, start)
+}
+
+// Redraw charts the loaded bars again with the current style and overlays,
+// without fetching them. Forecasts come from the cache unless their inputs
+// changed, and a view that is still loading is left to pick the settings up
+func (v *symbolView) Redraw() {
+	if v.busy || v.loaded == nil || v.mode.Value() != modePrice {
+		return
+	}
+	seq := atomic.AddInt64(&v.seq, 1)
+	v.setBusy(true)
+	symbol, data := v.symbol, v.loaded
+	go func() {
+		v.draw(symbol, data, seq, time.Now())
+		if atomic.LoadInt64(&v.seq) == seq {
+			v.setBusy(false)
+		}
+	}()
+}
+
+// draw charts data, the bars of symbol, then adds the forecast. Like load it
+// gives up once a newer load, seq, has started
+func (v *symbolView) draw(symbol string, data []StockData, seq int64, start time.Time) {
+	status := v.status
+	prices := make([]float64, len(data))
+	for i, d := range data {
+		prices[i] = d.Close
+	}
+
+	log.Printf("Prices for %s: %v\n", symbol, prices)
+	v.prices = prices
+	v.barDays = settings.Get().BarDays
+	bars := aggregateBars(data, v.barDays)
+	v.anomalies = detectAnomalies(bars)
+	v.metrics.SetText(fmt.Sprintf(lang.L("%s · %d unusual days"), formatMetrics(prices), len(v.anomalies)))
+	v.data.SetData(data, nil, 0, nil)
+	v.stats.SetData(symbol, data)
+
+	switch mode := v.mode.Value(); mode {
+	case modePrice:
+	case modeCompare:
+		v.showCompare(data)
+		return
+	default:
+		v.showRelative(mode, data)
+		return
+	}
+
+	// The chart and forecast use the selected bar size
+	prices = make([]float64, len(bars))
+	dates := make([]string, len(bars))
+	for i, b := range bars {
+		prices[i] = b.Close
+		dates[i] = b.Date
+	}
+
+	// Show the price history straight away, the forecast is added once it's ready
+	anomalies := anomalyIndexes(v.anomalies)
+	var candles []StockData
+	v.drawn = v.style.Value()
+	if v.drawn == styleCandles {
+		candles = bars
+	}
+	var volume []float64
+	if settings.Get().ShowVolume {
+		volume = make([]float64, len(bars))
+		for i, b := range bars {
+			volume[i] = b.Volume
+		}
+	}
+	averages := movingAverages(prices, settings.Get().MovingAverages)
+	benchmark, benchmarkValues := v.loadBenchmark(symbol, bars)
+	var envelope *bollingerBands
+	if b := settings.Get().Bollinger; b.Show {
+		envelope = bollinger(prices, b)
+	}
+	v.showChart(chartData{Symbol: symbol, Prices: prices, Dates: dates, BarDays: v.barDays, WindowDays: windowDays(settings.Get().ChartWindow), LogScale: settings.Get().LogScale, Percent: settings.Get().PercentReturn, Drawdown: settings.Get().ShowDrawdown, Anomalies: anomalies, Candles: candles, Volume: volume, Averages: averages, Bollinger: envelope, Annotations: symbolAnnotations(symbol), BenchmarkSymbol: benchmark, Benchmark: benchmarkValues})
+
+	if len(prices) < 2 { // Ensure enough data for predictions
+		log.Println("Not enough data points for predictions.")
+		status.Set("Fetched %d bars for %s, not enough data points to forecast", len(data), symbol)
+		return
+	}
+	status.Set("Fetched %d bars for %s in %s, forecasting...", len(data), symbol, time.Since(start).Round(time.Millisecond))
+
+	predictions, model, err := predictPrices(symbol, prices)
+	if atomic.LoadInt64(&v.seq) != seq {
+		return // a newer fetch has replaced this chart
+	}
+	if err != nil {
+		log.Println("Error calling prediction:", err)
+		status.Fail(err, "%s forecast failed for %s", model, symbol)
+		return
+	}
+
+	bands, err := intervalBands(model, len(predictions))
+	if err != nil {
+		log.Println("Error computing prediction intervals:", err)
+	}
+
+	volText := ""
+	if settings.Get().VolatilityBand {
+		band, vol, err := volatilityBand(prices, predictions)
+		if err != nil {
+			log.Println("Error fitting GARCH:", err)
+		} else {
+			bands = append(bands, band)
+			volText = fmt.Sprintf(lang.L(", next day volatility %.2f%% (%.1f%% annualized)"), vol*100, vol*math.Sqrt(tradingDaysPerYear)*100)
+		}
+	}
+
+	members, err := ensembleMembers(model, len(predictions))
+	if err != nil {
+		log.Println("Error computing ensemble members:", err)
+	}
+	if settings.Get().Baselines {
+		members = append(members, baselineForecasts(prices, len(predictions))...)
+	}
+
+	chart := chartData{Symbol: symbol, Prices: prices, Dates: dates, BarDays: v.barDays, WindowDays: windowDays(settings.Get().ChartWindow), LogScale: settings.Get().LogScale, Percent: settings.Get().PercentReturn, Drawdown: settings.Get().ShowDrawdown, Predictions: predictions, Bands: bands, Members: members, Anomalies: anomalies, Candles: candles, Volume: volume, Averages: averages, Bollinger: envelope, Annotations: symbolAnnotations(symbol), BenchmarkSymbol: benchmark, Benchmark: benchmarkValues}
+	v.showChart(chart)
+	v.data.SetData(data, dates, v.barDays, predictions)
+	status.Set("Fetched %d bars for %s and forecast %d days with %s in %s%s", len(data), symbol, len(predictions), model, time.Since(start).Round(time.Millisecond), volText)
+}
+
+// loadBenchmark lines the benchmark overlay up with bars, if it is turned on
+// and differs from symbol. Its bars are fetched once per load
+func (v *symbolView) loadBenchmark(symbol string, bars []StockData) (string, []float64) {
+	s := settings.Get()
+	benchmark := strings.ToUpper(strings.TrimSpace(s.Benchmark))
+	if !s.ShowBenchmark || benchmark == "" || benchmark == symbol {
+		return "", nil
+	}
+	if v.benchmark == nil || v.benchSym != benchmark {
+		data, err := fetchStockData(benchmark, s.LookbackMonths)
+		if err != nil {
+			log.Println("Error fetching benchmark data:", err)
+			v.status.Set("Fetch failed for benchmark %s: %v", benchmark, err)
+			return "", nil
+		}
+		v.benchmark, v.benchSym = data, benchmark
+	}
+	values, ok := rebase(bars, v.benchmark)
+	if !ok {
+		return "", nil
+	}
+	return benchmark, values
+}
+
+// showRelative charts the symbol's cumulative return net of the market (scaled
+// by its beta) or of its sector fund
+func (v *symbolView) showRelative(mode string, data []StockData) {
+	benchmark := benchmarkSymbol
+	if mode == modeSectorRelative {
+		benchmark = strings.ToUpper(strings.TrimSpace(v.sector.Text))
+		if benchmark == "" {
+			v.status.Set("No sector ETF known for %s, enter one to compare against", v.symbol)
+			return
+		}
+	}
+
+	bench, err := fetchStockData(benchmark, settings.Get().LookbackMonths)
+	if err != nil {
+		log.Println("Error fetching benchmark data:", err)
+		v.status.Fail(err, "Fetch failed for %s", benchmark)
+		return
+	}
+	ra, rb := alignReturns(data, bench)
+	if len(ra) < 2 {
+		v.status.Set("Not enough overlapping data between %s and %s", v.symbol, benchmark)
+		return
+	}
+
+	k := 1.0
+	title := fmt.Sprintf(lang.L("%s return minus %s"), v.symbol, benchmark)
+	if mode == modeBetaAdjusted {
+		k = beta(ra, rb)
+		title = fmt.Sprintf(lang.L("%s return minus %.2f × %s"), v.symbol, k, benchmark)
+	}
+
+	excess := cumulativeExcess(ra, rb, k)
+	chart, err := plotRelative(excess, title)
+	if err != nil {
+		log.Println("Error plotting data:", err)
+		v.status.Fail(err, "Plot failed for %s", v.symbol)
+		v.showText(fallbackText(title+" (%)", excess, func(i int) string { return fmt.Sprintf(lang.L("Day %d"), i) }))
+		return
+	}
+	v.showPlot(chart)
+	v.status.Set("Charted %s against %s (beta %.2f)", v.symbol, benchmark, beta(ra, rb))
+}
+
+// showCompare charts the symbol and the ones entered to compare with, each
+// rebased to 100 on the first day
+func (v *symbolView) showCompare(data []StockData) {
+	others := compareSymbols(v.compare.Text, v.symbol)
+	if len(others) == 0 {
+		v.status.Set("Enter symbols to compare %s with", v.symbol)
+		return
+	}
+
+	base, _ := rebase(data, data)
+	series := []comparedSeries{{Symbol: v.symbol, Values: base}}
+	var skipped []string
+	for _, symbol := range others {
+		symbol, _ = resolveSymbol(symbol)
+		other, err := fetchStockData(symbol, settings.Get().LookbackMonths)
+		if err != nil {
+			log.Println("Error fetching comparison data:", err)
+			skipped = append(skipped, symbol)
+			continue
+		}
+		values, ok := rebase(data, other)
+		if !ok {
+			skipped = append(skipped, symbol)
+			continue
+		}
+		series = append(series, comparedSeries{Symbol: symbol, Values: values})
+	}
+	if len(series) < 2 {
+		v.status.Set("No data to compare %s with for %s", v.symbol, strings.Join(skipped, ", "))
+		return
+	}
+
+	chart, err := plotCompare(series, data)
+	if err != nil {
+		log.Println("Error plotting data:", err)
+		v.status.Fail(err, "Plot failed for %s", v.symbol)
+		var text []string
+		for _, s := range series {
+			text = append(text, fallbackText(fmt.Sprintf(lang.L("%s rebased to 100"), s.Symbol), s.Values, func(i int) string { return shortDate(data[i].Date) }))
+		}
+		v.showText(strings.Join(text, "\n"))
+		return
+	}
+	v.showPlot(chart)
+	if len(skipped) > 0 {
+		v.status.Set("Compared %s with %d symbols, no data for %s", v.symbol, len(series)-1, strings.Join(skipped, ", "))
+		return
+	}
+	v.status.Set("Compared %s with %d symbols", v.symbol, len(series)-1)
+}
+
+// UpdateTheme recolors the parts of the view not restyled by the theme and
+// redraws its chart in the new colors
+func (v *symbolView) UpdateTheme() {
+	v.price.Color = theme.ForegroundColor()
+	v.price.Refresh()
+	for _, o := range v.chart.Objects {
+		o.Refresh()
+	}
+}
+
+// showQuote updates the last price and day change, flashing the price when a refresh moved it
+func (v *symbolView) showQuote(symbol string, data []StockData) {
+	last := data[len(data)-1].Close
+	if symbol == v.symbol && v.lastClose != 0 && last != v.lastClose {
+		v.flashQuote(last > v.lastClose)
+	}
+	v.lastClose = last
+
+	v.price.Text = formatPrice(last, symbolCurrency(symbol))
+	v.price.Refresh()
+
+	v.change.Text = ""
+	if len(data) > 1 {
+		prev := data[len(data)-2].Close
+		diff := last - prev
+		v.change.Text = formatChange(diff, diff/prev*100, symbolCurrency(symbol))
+		v.change.Color = directionColor(diff)
+	}
+	v.change.Refresh()
+}
+
+// loadIntraday fills the heat strip with today's hourly moves while the market is open
+func (v *symbolView) loadIntraday(symbol string) {
+	if !marketOpen(time.Now()) {
+		v.heat.SetChanges(nil)
+		return
+	}
+	bars, err := fetchIntradayData(symbol)
+	if err != nil {
+		log.Println("Error fetching intraday data:", err)
+		return
+	}
+	v.heat.SetChanges(hourlyChanges(bars))
+}
+
+// flashQuote briefly highlights the price green for an uptick or red for a downtick
+func (v *symbolView) flashQuote(up bool) {
+	c := theme.ErrorColor()
+	if up {
+		c = theme.SuccessColor()
+	}
+	r, g, b, _ := c.RGBA()
+	start := color.NRGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: 0x99}
+	end := color.NRGBA{R: start.R, G: start.G, B: start.B, A: 0}
+
+	canvas.NewColorRGBAAnimation(start, end, time.Second, func(c color.Color) {
+		v.flash.FillColor = c
+		v.flash.Refresh()
+	}).Start()
+}
+
+// directionColor picks green for gains, red for losses and the normal text color when flat
+func directionColor(diff float64) color.Color {
+	switch {
+	case diff > 0:
+		return theme.SuccessColor()
+	case diff < 0:
+		return theme.ErrorColor()
+	default:
+		return theme.ForegroundColor()
+	}
+}
+
+// UpdateAge refreshes the data age label and shows the stale badge once the data is too old
+func (v *symbolView) UpdateAge() {
+	if v.fetchedAt.IsZero() {
+		return
+	}
+	age := time.Since(v.fetchedAt)
+	v.age.SetText(fmt.Sprintf(lang.L("%s updated %s ago"), v.symbol, formatAge(age)))
+
+	threshold := time.Duration(settings.Get().StaleAfterMinutes) * time.Minute
+	if threshold > 0 && age > threshold {
+		v.stale.Show()
+	} else {
+		v.stale.Hide()
+	}
+}
+
+// formatAge renders a duration as a short human readable age
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return lang.L("less than a minute")
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
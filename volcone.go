@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/lang"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// volatilityConeWindows are the realized volatility lookbacks in trading days
+var volatilityConeWindows = []int{10, 20, 60, 120}
+
+// rollingVolatility returns the annualized realized volatility of every window of log returns
+func rollingVolatility(prices []float64, window int) []float64 {
+	if len(prices) <= window {
+		return nil
+	}
+	returns := make([]float64, len(prices)-1)
+	for i := 1; i < len(prices); i++ {
+		returns[i-1] = math.Log(prices[i] / prices[i-1])
+	}
+
+	vols := make([]float64, 0, len(returns)-window+1)
+	for end := window; end <= len(returns); end++ {
+		r := returns[end-window : end]
+		mean := 0.0
+		for _, x := range r {
+			mean += x
+		}
+		mean /= float64(window)
+		ss := 0.0
+		for _, x := range r {
+			ss += (x - mean) * (x - mean)
+		}
+		vols = append(vols, math.Sqrt(ss/float64(window-1)*tradingDaysPerYear))
+	}
+	return vols
+}
+
+// percentile returns the p-th percentile (0-100) of values using linear interpolation
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	pos := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(pos-float64(lo))
+}
+
+// plotVolatilityCone builds a chart of the historical volatility
+// percentiles per window with the current realized volatility on top
+func plotVolatilityCone(prices []float64, symbol string) (*plot.Plot, error) {
+	p := newPlot()
+	p.Title.Text = fmt.Sprintf(lang.L("Volatility Cone for %s"), symbol)
+	p.X.Label.Text = lang.L("Window (days)")
+	p.Y.Label.Text = lang.L("Annualized volatility (%)")
+
+	levels := []struct {
+		name string
+		pct  float64
+		col  color.RGBA
+	}{
+		{"Max", 100, color.RGBA{R: 128, G: 128, B: 128, A: 255}},
+		{"75th", 75, color.RGBA{B: 255, A: 255}},
+		{"Median", 50, color.RGBA{G: 160, A: 255}},
+		{"25th", 25, color.RGBA{B: 255, A: 255}},
+		{"Min", 0, color.RGBA{R: 128, G: 128, B: 128, A: 255}},
+	}
+
+	current := plotter.XYs{}
+	lines := make([]plotter.XYs, len(levels))
+	for _, w := range volatilityConeWindows {
+		vols := rollingVolatility(prices, w)
+		if len(vols) == 0 {
+			continue
+		}
+		for i, lvl := range levels {
+			lines[i] = append(lines[i], plotter.XY{X: float64(w), Y: percentile(vols, lvl.pct) * 100})
+		}
+		current = append(current, plotter.XY{X: float64(w), Y: vols[len(vols)-1] * 100})
+	}
+	if len(current) == 0 {
+		return nil, fmt.Errorf("not enough data for a %d day window", volatilityConeWindows[0])
+	}
+
+	for i, lvl := range levels {
+		line, err := plotter.NewLine(lines[i])
+		if err != nil {
+			return nil, err
+		}
+		line.Color = lvl.col
+		line.Dashes = []vg.Length{vg.Points(4), vg.Points(2)}
+		p.Add(line)
+		p.Legend.Add(lang.L(lvl.name), line)
+	}
+
+	now, points, err := plotter.NewLinePoints(current)
+	if err != nil {
+		return nil, err
+	}
+	now.Color = color.RGBA{R: 255, A: 255}
+	points.Color = now.Color
+	p.Add(now, points)
+	p.Legend.Add(lang.L("Current"), now, points)
+
+	return p, nil
+}
+
+// showVolatilityCone opens a window with the volatility cone of the view's symbol
+func showVolatilityCone(a fyne.App, v *symbolView) {
+	if len(v.prices) == 0 {
+		v.status.Set("Fetch a symbol before opening the volatility cone")
+		return
+	}
+	chart, err := plotVolatilityCone(v.prices, v.symbol)
+	if err != nil {
+		v.status.Fail(err, "Volatility cone failed for %s", v.symbol)
+		return
+	}
+
+	w := a.NewWindow(lang.L("Volatility Cone") + " - " + v.symbol)
+	w.SetContent(newPlotImage(chart.Draw))
+	w.Resize(fyne.NewSize(800, 400))
+	w.Show()
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fyne.io/fyne/v2/lang"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// volumeBars is a plotter drawing daily volume as bars colored by the day's
+// direction, one unit per bar like the price plot
+type volumeBars struct {
+	volume []float64
+	closes []float64 // closes matching volume, for the direction of each day
+}
+
+// Plot implements plot.Plotter
+func (v volumeBars) Plot(canvas draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&canvas)
+	width := (trX(1) - trX(0)) * 0.6
+	if width < vg.Points(1) {
+		width = vg.Points(1)
+	}
+	for i, vol := range v.volume {
+		col := candleUp
+		if i > 0 && v.closes[i] < v.closes[i-1] {
+			col = candleDown
+		}
+		x := trX(float64(i))
+		if x < canvas.Min.X || x > canvas.Max.X {
+			continue
+		}
+		canvas.FillPolygon(col, []vg.Point{
+			{X: x - width/2, Y: trY(0)},
+			{X: x + width/2, Y: trY(0)},
+			{X: x + width/2, Y: trY(vol)},
+			{X: x - width/2, Y: trY(vol)},
+		})
+	}
+}
+
+// DataRange implements plot.DataRanger
+func (v volumeBars) DataRange() (xmin, xmax, ymin, ymax float64) {
+	for _, vol := range v.volume {
+		if vol > ymax {
+			ymax = vol
+		}
+	}
+	return -0.5, float64(len(v.volume)) - 0.5, 0, ymax
+}
+
+// hasVolume reports whether any bar traded volume
+func hasVolume(volume []float64) bool {
+	for _, v := range volume {
+		if v > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// volumePlot builds the volume panel for the price plot p, sharing its X axis
+func volumePlot(bars volumeBars, p *plot.Plot) *plot.Plot {
+	vp := newPlot()
+	vp.Add(bars)
+	vp.X.Tick.Marker = p.X.Tick.Marker
+	vp.Y.Label.Text = lang.L("Volume")
+	vp.Y.Tick.Marker = volumeTicks{}
+	vp.X.Min, vp.X.Max = p.X.Min, p.X.Max
+	vp.Y.Min, vp.Y.Max = 0, 1
+	for i, v := range bars.volume {
+		if float64(i) >= p.X.Min && float64(i) <= p.X.Max && v > vp.Y.Max {
+			vp.Y.Max = v
+		}
+	}
+	return vp
+}
+
+// volumeTicks labels volume in thousands, millions or billions
+type volumeTicks struct{}
+
+// Ticks implements plot.Ticker
+func (volumeTicks) Ticks(min, max float64) []plot.Tick {
+	ticks := plot.DefaultTicks{}.Ticks(min, max)
+	for i := range ticks {
+		if ticks[i].Label != "" {
+			ticks[i].Label = formatCompact(ticks[i].Value)
+		}
+	}
+	return ticks
+}
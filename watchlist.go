@@ -0,0 +1,290 @@
+package main
+
+import (
+	"log"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// watchQuote is the last close and day change of a watched symbol
+type watchQuote struct {
+	Close, Change, Percent float64
+	Hourly                 []float64 // today's hourly percent changes, while the market is open
+	OffHigh                float64   // percent below the 52-week high
+	Streak                 int       // consecutive up days, negative for down days
+	SinceDrawdown          int       // days since the last 5% drawdown, -1 if there was none in the year
+}
+
+// watchlistMonths is the history fetched per watched symbol, enough for its 52-week figures
+const watchlistMonths = 12
+
+// watchlistPanel lists the saved symbols with their last price and day
+// change, and a heat strip of today's hours during the session. Clicking one
+// opens its chart
+type watchlistPanel struct {
+	onOpen func(symbol string)
+
+	mu        sync.Mutex
+	symbols   []string
+	quotes    map[string]watchQuote
+	onQuote   map[int]func() // told when the symbols or their quotes change, by registration
+	nextQuote int            // the key of the next OnQuote listener
+
+	selected widget.ListItemID
+	moving   bool // the selection follows a reordered row, don't open it
+	list     *widget.List
+	entry    *widget.Entry
+	content  fyne.CanvasObject
+}
+
+// newWatchlistPanel creates the sidebar for the saved watchlist
+func newWatchlistPanel(onOpen func(string)) *watchlistPanel {
+	p := &watchlistPanel{onOpen: onOpen, quotes: map[string]watchQuote{}, onQuote: map[int]func(){}, selected: -1}
+
+	p.list = widget.NewList(
+		func() int {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			return len(p.symbols)
+		},
+		func() fyne.CanvasObject {
+			symbol := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+			price := widget.NewLabel("")
+			change := canvas.NewText("", theme.ForegroundColor())
+			strip := newHeatStrip(rowHeatBlock)
+			return container.NewBorder(nil, nil, symbol, container.NewHBox(price, change), container.NewCenter(strip.box))
+		},
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			p.mu.Lock()
+			if id >= len(p.symbols) {
+				p.mu.Unlock()
+				return
+			}
+			symbol := p.symbols[id]
+			q, ok := p.quotes[symbol]
+			p.mu.Unlock()
+
+			// Border puts the center object first, then the left and right ones
+			row := o.(*fyne.Container)
+			strip := heatStrip{box: row.Objects[0].(*fyne.Container).Objects[0].(*fyne.Container), block: rowHeatBlock}
+			strip.SetChanges(q.Hourly)
+			row.Objects[1].(*widget.Label).SetText(symbol)
+			quote := row.Objects[2].(*fyne.Container)
+			price, change := quote.Objects[0].(*widget.Label), quote.Objects[1].(*canvas.Text)
+			if !ok {
+				price.SetText("…")
+				change.Text = ""
+			} else {
+				price.SetText(formatPrice(q.Close, symbolCurrency(symbol)))
+				change.Text = formatPercent(q.Percent)
+				change.Color = directionColor(q.Change)
+			}
+			change.Refresh()
+		})
+	p.list.OnSelected = func(id widget.ListItemID) {
+		p.selected = id
+		p.mu.Lock()
+		symbol := ""
+		if id < len(p.symbols) {
+			symbol = p.symbols[id]
+		}
+		p.mu.Unlock()
+		if !p.moving && symbol != "" {
+			p.onOpen(symbol)
+		}
+	}
+	p.list.OnUnselected = func(widget.ListItemID) { p.selected = -1 }
+
+	p.entry = widget.NewEntry()
+	p.entry.SetPlaceHolder(lang.L("Add symbol"))
+	add := func() {
+		p.add(p.entry.Text)
+		p.entry.SetText("")
+	}
+	p.entry.OnSubmitted = func(string) { add() }
+
+	// Buttons are labelled as well as iconed, so they can be told apart
+	// without seeing the icons
+	buttons := container.NewGridWithColumns(2,
+		widget.NewButtonWithIcon(lang.L("Add"), theme.ContentAddIcon(), add),
+		widget.NewButtonWithIcon(lang.L("Remove"), theme.ContentRemoveIcon(), p.remove),
+		widget.NewButtonWithIcon(lang.L("Up"), theme.MoveUpIcon(), func() { p.move(-1) }),
+		widget.NewButtonWithIcon(lang.L("Down"), theme.MoveDownIcon(), func() { p.move(1) }),
+	)
+	title := widget.NewLabelWithStyle(lang.L("Watchlist"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	p.content = container.NewBorder(title, container.NewVBox(p.entry, buttons), nil, nil, p.list)
+
+	p.SetSymbols(settings.Get().Watchlist)
+	return p
+}
+
+// SetSymbols shows symbols, fetching the quotes not loaded yet
+func (p *watchlistPanel) SetSymbols(symbols []string) {
+	p.mu.Lock()
+	if slices.Equal(p.symbols, symbols) {
+		p.mu.Unlock()
+		return
+	}
+	p.symbols = slices.Clone(symbols)
+	var missing []string
+	for _, symbol := range symbols {
+		if _, ok := p.quotes[symbol]; !ok {
+			missing = append(missing, symbol)
+		}
+	}
+	p.mu.Unlock()
+
+	p.list.UnselectAll()
+	p.list.Refresh()
+	p.notifyQuote()
+	for _, symbol := range missing {
+		go p.loadQuote(symbol)
+	}
+}
+
+// Refresh fetches the quotes of all the symbols again
+func (p *watchlistPanel) Refresh() {
+	p.mu.Lock()
+	symbols := slices.Clone(p.symbols)
+	p.mu.Unlock()
+	for _, symbol := range symbols {
+		go p.loadQuote(symbol)
+	}
+}
+
+// Top returns the first symbol and its quote, ok is false while the quote
+// is loading and symbol is empty if the watchlist is
+func (p *watchlistPanel) Top() (symbol string, q watchQuote, ok bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.symbols) == 0 {
+		return "", watchQuote{}, false
+	}
+	q, ok = p.quotes[p.symbols[0]]
+	return p.symbols[0], q, ok
+}
+
+// OnQuote registers fn to be called when the symbols or their quotes change,
+// returning a func that removes it again
+func (p *watchlistPanel) OnQuote(fn func()) (remove func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key := p.nextQuote
+	p.nextQuote++
+	p.onQuote[key] = fn
+	return func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		delete(p.onQuote, key)
+	}
+}
+
+// notifyQuote calls the OnQuote listeners
+func (p *watchlistPanel) notifyQuote() {
+	p.mu.Lock()
+	listeners := slices.Collect(maps.Values(p.onQuote))
+	p.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn()
+	}
+}
+
+// Quotes returns the symbols with the quotes loaded so far
+func (p *watchlistPanel) Quotes() ([]string, map[string]watchQuote) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.symbols), maps.Clone(p.quotes)
+}
+
+// loadQuote fetches the last close and day change of symbol, with its streak
+// and 52-week high and drawdown figures
+func (p *watchlistPanel) loadQuote(symbol string) {
+	data, err := fetchStockData(symbol, watchlistMonths)
+	if err != nil || len(data) < 2 {
+		log.Println("Error fetching watchlist quote for", symbol, err)
+		return
+	}
+	last, prev := data[len(data)-1].Close, data[len(data)-2].Close
+	var hourly []float64
+	if marketOpen(time.Now()) {
+		if bars, err := fetchIntradayData(symbol); err != nil {
+			log.Println("Error fetching intraday data for", symbol, err)
+		} else {
+			hourly = hourlyChanges(bars)
+		}
+	}
+	closes := make([]float64, len(data))
+	for i, d := range data {
+		closes[i] = d.Close
+	}
+	p.mu.Lock()
+	p.quotes[symbol] = watchQuote{Close: last, Change: last - prev, Percent: (last/prev - 1) * 100, Hourly: hourly,
+		OffHigh: percentOffHigh(closes), Streak: streak(closes), SinceDrawdown: daysSinceDrawdown(closes, 5)}
+	p.mu.Unlock()
+	p.list.Refresh()
+	p.notifyQuote()
+}
+
+// add appends symbol to the watchlist unless it is already on it
+func (p *watchlistPanel) add(symbol string) {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	p.mu.Lock()
+	if symbol == "" || slices.Contains(p.symbols, symbol) {
+		p.mu.Unlock()
+		return
+	}
+	symbols := append(slices.Clone(p.symbols), symbol)
+	p.mu.Unlock()
+	p.save(symbols)
+}
+
+// remove drops the selected symbol from the watchlist
+func (p *watchlistPanel) remove() {
+	p.mu.Lock()
+	if p.selected < 0 || p.selected >= len(p.symbols) {
+		p.mu.Unlock()
+		return
+	}
+	symbols := slices.Delete(slices.Clone(p.symbols), p.selected, p.selected+1)
+	p.mu.Unlock()
+	p.save(symbols)
+}
+
+// move shifts the selected symbol step places up or down the list
+func (p *watchlistPanel) move(step int) {
+	p.mu.Lock()
+	i, j := p.selected, p.selected+step
+	if i < 0 || i >= len(p.symbols) || j < 0 || j >= len(p.symbols) {
+		p.mu.Unlock()
+		return
+	}
+	symbols := slices.Clone(p.symbols)
+	symbols[i], symbols[j] = symbols[j], symbols[i]
+	p.mu.Unlock()
+	p.save(symbols)
+
+	p.moving = true
+	p.list.Select(j)
+	p.moving = false
+}
+
+// save shows symbols and stores them in the settings
+func (p *watchlistPanel) save(symbols []string) {
+	p.SetSymbols(symbols)
+	s := settings.Get()
+	s.Watchlist = symbols
+	if err := settings.Set(s); err != nil {
+		log.Println("Error saving settings:", err)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"log"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+)
+
+// restoreWindow sizes win, splits sidebar and reopens the tabs as they were
+// when the app was last closed
+func restoreWindow(win fyne.Window, sidebar *container.Split, tabs *workspace) {
+	w := settings.Get().Window
+	if w.Width > 0 && w.Height > 0 {
+		win.Resize(fyne.NewSize(w.Width, w.Height))
+	}
+	if w.SidebarSplit > 0 && w.SidebarSplit < 1 {
+		sidebar.Offset = w.SidebarSplit
+	}
+	tabs.Restore(w.Tabs, w.SelectedTab)
+}
+
+// saveWindowState stores the size of win, the split offsets and the open
+// tabs so restoreWindow can bring them back
+func saveWindowState(win fyne.Window, sidebar *container.Split, tabs *workspace) {
+	s := settings.Get()
+	size := win.Canvas().Size()
+	s.Window.Width, s.Window.Height = size.Width, size.Height
+	s.Window.SidebarSplit = sidebar.Offset
+	if v := tabs.Current(); v != nil {
+		s.Window.StatsSplit = v.split.Offset
+	}
+	s.Window.Tabs, s.Window.SelectedTab = tabs.Symbols()
+	if err := settings.Set(s); err != nil {
+		log.Println("Error saving settings:", err)
+	}
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// newTabTitle labels a tab before a symbol is loaded into it
+const newTabTitle = "New"
+
+// workspace keeps a tab per open symbol, each with its own chart and
+// forecast, so switching between names doesn't fetch them again
+type workspace struct {
+	app    fyne.App
+	status *statusBar
+	tabs   *container.AppTabs
+	views  map[*container.TabItem]*symbolView
+
+	onBusy func(bool) // told whether the current tab is loading
+}
+
+// newWorkspace creates a workspace with one empty tab. Views popped out of
+// it open in their own windows
+func newWorkspace(a fyne.App, status *statusBar) *workspace {
+	w := &workspace{app: a, status: status, views: map[*container.TabItem]*symbolView{}}
+	w.tabs = container.NewAppTabs()
+	w.tabs.OnSelected = func(*container.TabItem) {
+		w.notifyBusy()
+		if v := w.Current(); v != nil {
+			v.UpdateAge()
+		}
+	}
+	w.NewTab()
+	return w
+}
+
+// Current returns the view of the selected tab
+func (w *workspace) Current() *symbolView {
+	return w.views[w.tabs.Selected()]
+}
+
+// Refresh reloads the symbol of the current tab unless it is empty or
+// already loading
+func (w *workspace) Refresh() {
+	if v := w.Current(); v.symbol != "" && !v.busy {
+		v.Load(v.symbol)
+	}
+}
+
+// Views returns the views of all tabs in order
+func (w *workspace) Views() []*symbolView {
+	views := make([]*symbolView, 0, len(w.tabs.Items))
+	for _, tab := range w.tabs.Items {
+		views = append(views, w.views[tab])
+	}
+	return views
+}
+
+// NewTab adds an empty tab and selects it
+func (w *workspace) NewTab() *symbolView {
+	v := newSymbolView(w.status)
+	tab := container.NewTabItem(lang.L(newTabTitle), v.content)
+	v.onBusy = func(bool) {
+		// Follow renames resolved while loading
+		if v.symbol != "" && tab.Text != v.symbol {
+			tab.Text = v.symbol
+			w.tabs.Refresh()
+			if v.popout != nil {
+				v.popout.SetTitle(v.symbol)
+			}
+		}
+		w.notifyBusy()
+	}
+	v.onPopOut = func() {
+		if v.popout != nil {
+			v.popout.Close()
+		} else {
+			w.popOut(tab)
+		}
+	}
+	w.views[tab] = v
+	w.tabs.Append(tab)
+	w.tabs.Select(tab)
+	return v
+}
+
+// Open shows symbol, switching to its tab if it is already open and
+// otherwise loading it into the current tab when that is empty, or a new one
+func (w *workspace) Open(symbol string) {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if symbol == "" {
+		return
+	}
+	for _, tab := range w.tabs.Items {
+		// A tab titled symbol may still be fetching it for the first time
+		if w.views[tab].symbol == symbol || tab.Text == symbol {
+			w.tabs.Select(tab)
+			return
+		}
+	}
+
+	v := w.Current()
+	if v == nil || v.symbol != "" || v.busy {
+		v = w.NewTab()
+	}
+	w.tabs.Selected().Text = symbol
+	w.tabs.Refresh()
+	v.Load(symbol)
+}
+
+// OpenFile charts data read from the file called name, replacing the tab of
+// the same file if it is open and otherwise using the current tab when that
+// is empty, or a new one
+func (w *workspace) OpenFile(name string, data []StockData) {
+	var v *symbolView
+	for _, tab := range w.tabs.Items {
+		if w.views[tab].file != nil && w.views[tab].symbol == name {
+			w.tabs.Select(tab)
+			v = w.views[tab]
+		}
+	}
+	if v == nil {
+		v = w.Current()
+		if v == nil || v.symbol != "" || v.busy {
+			v = w.NewTab()
+		}
+		w.tabs.Selected().Text = name
+		w.tabs.Refresh()
+	}
+	v.LoadFile(name, data)
+}
+
+// Symbols returns the symbols of the open tabs in order, skipping empty
+// ones and files, and the index of the selected tab among them
+func (w *workspace) Symbols() (symbols []string, selected int) {
+	for _, tab := range w.tabs.Items {
+		if tab.Text == lang.L(newTabTitle) || w.views[tab].file != nil {
+			continue
+		}
+		if tab == w.tabs.Selected() {
+			selected = len(symbols)
+		}
+		symbols = append(symbols, tab.Text)
+	}
+	return symbols, selected
+}
+
+// Restore opens a tab for each of symbols and selects the one at selected
+func (w *workspace) Restore(symbols []string, selected int) {
+	for _, symbol := range symbols {
+		w.Open(symbol)
+	}
+	if selected >= 0 && selected < len(w.tabs.Items) {
+		w.tabs.SelectIndex(selected)
+	}
+}
+
+// PopOutCurrent moves the selected tab's view into a window of its own, or
+// brings it back if it is already in one
+func (w *workspace) PopOutCurrent() {
+	if v := w.Current(); v != nil {
+		v.onPopOut()
+	}
+}
+
+// popOut moves the view of tab into a new window, so several charts can be
+// kept in sight at once, leaving a placeholder in the tab. Closing the window
+// puts the view back
+func (w *workspace) popOut(tab *container.TabItem) {
+	v := w.views[tab]
+	if v.symbol == "" {
+		w.status.Set("Fetch a symbol before opening it in a new window")
+		return
+	}
+
+	win := w.app.NewWindow(v.symbol)
+	show := widget.NewButtonWithIcon(lang.L("Show Window"), theme.ViewFullScreenIcon(), win.RequestFocus)
+	dock := widget.NewButtonWithIcon(lang.L("Dock"), theme.ViewRestoreIcon(), win.Close)
+	message := widget.NewLabel(fmt.Sprintf(lang.L("%s is open in its own window"), v.symbol))
+	tab.Content = container.NewCenter(container.NewVBox(message, container.NewHBox(show, dock)))
+	w.tabs.Refresh()
+
+	v.popout = win
+	v.detach.SetText(lang.L("Dock"))
+	v.detach.SetIcon(theme.ViewRestoreIcon())
+	win.SetOnClosed(func() {
+		v.popout = nil
+		v.detach.SetText(lang.L("Pop Out"))
+		v.detach.SetIcon(theme.ViewFullScreenIcon())
+		tab.Content = v.content
+		w.tabs.Refresh()
+	})
+	win.Canvas().AddShortcut(appShortcut(fyne.KeyR), func(fyne.Shortcut) {
+		if !v.busy {
+			v.Load(v.symbol)
+		}
+	})
+	win.SetContent(v.content)
+	win.Resize(fyne.NewSize(900, 600))
+	win.Show()
+}
+
+// DockAll closes the windows of popped out views, putting them back in their tabs
+func (w *workspace) DockAll() {
+	for _, v := range w.Views() {
+		if v.popout != nil {
+			v.popout.Close()
+		}
+	}
+}
+
+// CloseCurrent closes the selected tab and the window its view was popped
+// out into, keeping at least one tab open
+func (w *workspace) CloseCurrent() {
+	tab := w.tabs.Selected()
+	if tab == nil {
+		return
+	}
+	if v := w.views[tab]; v.popout != nil {
+		v.popout.Close()
+	}
+	delete(w.views, tab)
+	w.tabs.Remove(tab)
+	if len(w.tabs.Items) == 0 {
+		w.NewTab()
+	}
+	w.notifyBusy()
+}
+
+// ReloadAll fetches every open symbol again
+func (w *workspace) ReloadAll() {
+	for _, v := range w.Views() {
+		if v.symbol != "" {
+			v.Load(v.symbol)
+		}
+	}
+}
+
+// RedrawAll charts every open view again from the bars it already has, for
+// overlay changes that don't need new data
+func (w *workspace) RedrawAll() {
+	for _, v := range w.Views() {
+		v.Redraw()
+	}
+}
+
+// notifyBusy passes on whether the current tab is loading
+func (w *workspace) notifyBusy() {
+	if w.onBusy == nil {
+		return
+	}
+	v := w.Current()
+	w.onBusy(v != nil && v.busy)
+}